@@ -0,0 +1,159 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1beta1 "github.com/kudobuilder/kudo/pkg/apis/config/v1beta1"
+)
+
+// loadConfig builds the manager configuration from its defaults, the environment, the configuration file passed with
+// --config and the remaining flags, each overriding the former. The environment variables are still supported for
+// manifests created by older versions of 'kudo init'.
+func loadConfig(args []string) (*configv1beta1.ManagerConfiguration, error) {
+	cfg := configv1beta1.NewManagerConfiguration()
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+
+	defaults := configv1beta1.NewManagerConfiguration()
+	flags := defaults.DeepCopy()
+	var configFile, watchNamespaces string
+	var syncPeriod time.Duration
+
+	fs := flag.NewFlagSet("manager", flag.ContinueOnError)
+	fs.StringVar(&configFile, "config", "", "The manager configuration file, flags override the settings in the file.")
+	fs.DurationVar(&syncPeriod, "sync-period", 0, "The minimum frequency at which watched resources are reconciled.")
+	fs.IntVar(&flags.MaxConcurrentReconciles, "max-concurrent-reconciles", defaults.MaxConcurrentReconciles, "The maximum number of concurrent reconciles of each controller.")
+	fs.StringVar(&flags.MetricsBindAddress, "metrics-bind-address", defaults.MetricsBindAddress, "The address the metrics endpoint binds to, 0 disables it.")
+	fs.StringVar(&flags.HealthProbeBindAddress, "health-probe-bind-address", defaults.HealthProbeBindAddress, "The address the health probe endpoints bind to, 0 disables them.")
+	fs.StringVar(&watchNamespaces, "watch-namespaces", "", "The namespaces the manager is limited to separated by commas (default all namespaces).")
+	fs.BoolVar(&flags.Webhook.Enabled, "enable-webhooks", defaults.Webhook.Enabled, "Enable the admission webhooks.")
+	fs.IntVar(&flags.Webhook.Port, "webhook-port", defaults.Webhook.Port, "The port the webhook server listens on.")
+	fs.StringVar(&flags.Webhook.CertDir, "webhook-cert-dir", defaults.Webhook.CertDir, "The directory containing the tls.crt and tls.key of the webhook server.")
+	fs.BoolVar(&flags.LeaderElection.Enabled, "leader-elect", defaults.LeaderElection.Enabled, "Enable leader election between manager replicas.")
+	fs.StringVar(&flags.LeaderElection.Namespace, "leader-election-namespace", "", "The namespace of the leader election lock (default the namespace the manager runs in).")
+	fs.StringVar(&flags.LeaderElection.ID, "leader-election-id", defaults.LeaderElection.ID, "The name of the leader election lock.")
+	fs.StringVar(&flags.Logging.Level, "log-level", defaults.Logging.Level, "The minimum level of logged messages: debug, info or error.")
+	fs.StringVar(&flags.Logging.Format, "log-format", defaults.Logging.Format, "The format of logged messages: json or console.")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if configFile != "" {
+		data, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manager configuration: %v", err)
+		}
+		if err := cfg.Load(data); err != nil {
+			return nil, err
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "sync-period":
+			cfg.SyncPeriod = &metav1.Duration{Duration: syncPeriod}
+		case "max-concurrent-reconciles":
+			cfg.MaxConcurrentReconciles = flags.MaxConcurrentReconciles
+		case "metrics-bind-address":
+			cfg.MetricsBindAddress = flags.MetricsBindAddress
+		case "health-probe-bind-address":
+			cfg.HealthProbeBindAddress = flags.HealthProbeBindAddress
+		case "watch-namespaces":
+			cfg.WatchNamespaces = splitNamespaces(watchNamespaces)
+		case "enable-webhooks":
+			cfg.Webhook.Enabled = flags.Webhook.Enabled
+		case "webhook-port":
+			cfg.Webhook.Port = flags.Webhook.Port
+		case "webhook-cert-dir":
+			cfg.Webhook.CertDir = flags.Webhook.CertDir
+		case "leader-elect":
+			cfg.LeaderElection.Enabled = flags.LeaderElection.Enabled
+		case "leader-election-namespace":
+			cfg.LeaderElection.Namespace = flags.LeaderElection.Namespace
+		case "leader-election-id":
+			cfg.LeaderElection.ID = flags.LeaderElection.ID
+		case "log-level":
+			cfg.Logging.Level = flags.Logging.Level
+		case "log-format":
+			cfg.Logging.Format = flags.Logging.Format
+		}
+	})
+
+	if cfg.LeaderElection.Namespace == "" {
+		cfg.LeaderElection.Namespace = os.Getenv("POD_NAMESPACE")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyEnv reads the settings of the environment variables into the configuration:
+// - KUDO_SYNCPERIOD is the sync period as a duration, e.g. "10h"
+// - KUDO_REGISTRY_MIRRORS is a comma separated list of registry=mirror pairs, e.g. "docker.io=mirror.corp/dockerhub"
+// - KUDO_WATCH_NAMESPACES is a comma separated list of namespaces
+// - KUDO_LEADER_ELECTION, KUDO_LEADER_ELECTION_NAMESPACE and KUDO_LEADER_ELECTION_ID configure leader election
+// - ENABLE_WEBHOOKS enables the admission webhooks
+func applyEnv(cfg *configv1beta1.ManagerConfiguration) error {
+	if val, ok := os.LookupEnv("KUDO_SYNCPERIOD"); ok {
+		sync, err := time.ParseDuration(val)
+		if err != nil {
+			return fmt.Errorf("invalid sync period %q: %v", val, err)
+		}
+		cfg.SyncPeriod = &metav1.Duration{Duration: sync}
+	}
+
+	if val := os.Getenv("KUDO_REGISTRY_MIRRORS"); val != "" {
+		cfg.RegistryMirrors = map[string]string{}
+		for _, pair := range strings.Split(val, ",") {
+			parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("invalid registry mirror %q, expected registry=mirror", pair)
+			}
+			cfg.RegistryMirrors[parts[0]] = parts[1]
+		}
+	}
+
+	if namespaces := splitNamespaces(os.Getenv("KUDO_WATCH_NAMESPACES")); len(namespaces) > 0 {
+		cfg.WatchNamespaces = namespaces
+	}
+
+	if val := os.Getenv("KUDO_LEADER_ELECTION"); val != "" {
+		enabled, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("invalid leader election flag %q: %v", val, err)
+		}
+		cfg.LeaderElection.Enabled = enabled
+	}
+	if val := os.Getenv("KUDO_LEADER_ELECTION_NAMESPACE"); val != "" {
+		cfg.LeaderElection.Namespace = val
+	}
+	if val := os.Getenv("KUDO_LEADER_ELECTION_ID"); val != "" {
+		cfg.LeaderElection.ID = val
+	}
+
+	if strings.ToLower(os.Getenv("ENABLE_WEBHOOKS")) == "true" {
+		cfg.Webhook.Enabled = true
+	}
+	return nil
+}
+
+// splitNamespaces splits a comma separated list of namespaces
+func splitNamespaces(val string) []string {
+	var namespaces []string
+	for _, ns := range strings.Split(val, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
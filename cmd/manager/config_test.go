@@ -0,0 +1,122 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1beta1 "github.com/kudobuilder/kudo/pkg/apis/config/v1beta1"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kudo-manager-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "config.yaml")
+	config := `apiVersion: config.kudo.dev/v1beta1
+kind: ManagerConfiguration
+syncPeriod: 1h
+maxConcurrentReconciles: 2
+watchNamespaces: [from-file]
+leaderElection:
+  enabled: true
+`
+	if err := ioutil.WriteFile(configFile, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		args     []string
+		expected func(*configv1beta1.ManagerConfiguration)
+		err      string
+	}{
+		{
+			name:     "defaults",
+			expected: func(*configv1beta1.ManagerConfiguration) {},
+		},
+		{
+			name: "environment",
+			env:  map[string]string{"KUDO_SYNCPERIOD": "30m", "ENABLE_WEBHOOKS": "true", "KUDO_WATCH_NAMESPACES": "a, b", "POD_NAMESPACE": "kudo-system"},
+			expected: func(c *configv1beta1.ManagerConfiguration) {
+				c.SyncPeriod = &metav1.Duration{Duration: 30 * time.Minute}
+				c.Webhook.Enabled = true
+				c.WatchNamespaces = []string{"a", "b"}
+				c.LeaderElection.Namespace = "kudo-system"
+			},
+		},
+		{
+			name: "file overrides environment",
+			env:  map[string]string{"KUDO_SYNCPERIOD": "30m", "ENABLE_WEBHOOKS": "true"},
+			args: []string{"--config", configFile},
+			expected: func(c *configv1beta1.ManagerConfiguration) {
+				c.SyncPeriod = &metav1.Duration{Duration: time.Hour}
+				c.MaxConcurrentReconciles = 2
+				c.WatchNamespaces = []string{"from-file"}
+				c.Webhook.Enabled = true
+				c.LeaderElection.Enabled = true
+			},
+		},
+		{
+			name: "flags override file",
+			args: []string{"--config", configFile, "--max-concurrent-reconciles", "5", "--watch-namespaces", "x,y", "--leader-elect=false", "--log-format", "console"},
+			expected: func(c *configv1beta1.ManagerConfiguration) {
+				c.SyncPeriod = &metav1.Duration{Duration: time.Hour}
+				c.MaxConcurrentReconciles = 5
+				c.WatchNamespaces = []string{"x", "y"}
+				c.Logging.Format = configv1beta1.LogFormatConsole
+			},
+		},
+		{
+			name: "invalid environment",
+			env:  map[string]string{"KUDO_REGISTRY_MIRRORS": "docker.io"},
+			err:  `invalid registry mirror "docker.io", expected registry=mirror`,
+		},
+		{
+			name: "invalid flag value",
+			args: []string{"--max-concurrent-reconciles", "0"},
+			err:  "max concurrent reconciles must be at least 1, got 0",
+		},
+		{
+			name: "missing file",
+			args: []string{"--config", filepath.Join(dir, "missing.yaml")},
+			err:  "failed to read manager configuration: open " + filepath.Join(dir, "missing.yaml") + ": no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"KUDO_SYNCPERIOD", "KUDO_REGISTRY_MIRRORS", "KUDO_WATCH_NAMESPACES", "KUDO_LEADER_ELECTION", "KUDO_LEADER_ELECTION_NAMESPACE", "KUDO_LEADER_ELECTION_ID", "ENABLE_WEBHOOKS", "POD_NAMESPACE"} {
+				os.Unsetenv(name)
+			}
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer func() {
+				for k := range tt.env {
+					os.Unsetenv(k)
+				}
+			}()
+
+			cfg, err := loadConfig(tt.args)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			expected := configv1beta1.NewManagerConfiguration()
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
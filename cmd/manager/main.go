@@ -16,117 +16,187 @@ limitations under the License.
 package main
 
 import (
+	"flag"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
-	"time"
 
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	apiextenstionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	crzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/kudobuilder/kudo/pkg/apis"
+	configv1beta1 "github.com/kudobuilder/kudo/pkg/apis/config/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/controller"
 	"github.com/kudobuilder/kudo/pkg/controller/instance"
 	"github.com/kudobuilder/kudo/pkg/controller/operator"
 	"github.com/kudobuilder/kudo/pkg/controller/operatorversion"
 	"github.com/kudobuilder/kudo/pkg/version"
 )
 
-// parseSyncPeriod determines the minimum frequency at which watched resources are reconciled.
-// If the variable is present in the environment the duration is returned.
-func parseSyncPeriod() (*time.Duration, error) {
-	if val, ok := os.LookupEnv("KUDO_SYNCPERIOD"); ok {
-		sync, err := time.ParseDuration(val)
-		if err != nil {
-			return nil, err
-		}
-		return &sync, nil
+// setupLogger sets the logger used by the manager and controller-runtime. Messages are written to stderr as JSON, or
+// in a human readable format with the console format. Debug messages are only logged with the debug level.
+func setupLogger(level, format string) error {
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("invalid log level %q: %v", level, err)
 	}
-	return nil, nil
+
+	atomicLevel := zap.NewAtomicLevelAt(lvl)
+	development := format == configv1beta1.LogFormatConsole
+	ctrl.SetLogger(crzap.New(crzap.UseDevMode(development), crzap.Level(&atomicLevel)))
+	return nil
 }
 
 func main() {
+	cfg, err := loadConfig(os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to load manager configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := setupLogger(cfg.Logging.Level, cfg.Logging.Format); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to set up logging: %v\n", err)
+		os.Exit(1)
+	}
+	log := ctrl.Log.WithName("setup")
+
 	// Get version of KUDO
-	log.Printf("KUDO Version: %#v", version.Get())
+	log.Info("starting KUDO manager", "version", version.Get().GitVersion)
 
 	// create new controller-runtime manager
 
-	syncPeriod, err := parseSyncPeriod()
-	if err != nil {
-		log.Printf("unable to parse manager sync period variable: %v", err)
-		os.Exit(1)
+	if cfg.SyncPeriod != nil {
+		log.Info("setting up manager", "syncPeriod", cfg.SyncPeriod.Duration.String())
+	} else {
+		log.Info("setting up manager")
 	}
 
-	if syncPeriod != nil {
-		log.Print(fmt.Sprintf("setting up manager, sync-period is %v", syncPeriod))
-	} else {
-		log.Print("setting up manager")
+	for registry, mirror := range cfg.RegistryMirrors {
+		log.Info("mirroring images of registry", "registry", registry, "mirror", mirror)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		CertDir:    "/tmp/cert",
-		SyncPeriod: syncPeriod,
-	})
+	options := ctrl.Options{
+		SyncPeriod:              cfg.SyncPeriodDuration(),
+		MetricsBindAddress:      cfg.MetricsBindAddress,
+		HealthProbeBindAddress:  cfg.HealthProbeBindAddress,
+		Port:                    cfg.Webhook.Port,
+		CertDir:                 cfg.Webhook.CertDir,
+		LeaderElection:          cfg.LeaderElection.Enabled,
+		LeaderElectionNamespace: cfg.LeaderElection.Namespace,
+		LeaderElectionID:        cfg.LeaderElection.ID,
+	}
+	if options.LeaderElection {
+		log.Info("leader election enabled", "namespace", options.LeaderElectionNamespace, "lock", options.LeaderElectionID)
+	}
+
+	switch len(cfg.WatchNamespaces) {
+	case 0:
+		log.Info("watching all namespaces")
+	case 1:
+		log.Info("watching namespace", "namespace", cfg.WatchNamespaces[0])
+		options.Namespace = cfg.WatchNamespaces[0]
+	default:
+		log.Info("watching namespaces", "namespaces", cfg.WatchNamespaces)
+		options.NewCache = cache.MultiNamespacedCacheBuilder(cfg.WatchNamespaces)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
-		log.Printf("unable to start manager: %v", err)
+		log.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	log.Print("Registering Components")
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		log.Error(err, "unable to add health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("ping", healthz.Ping); err != nil {
+		log.Error(err, "unable to add readiness check")
+		os.Exit(1)
+	}
 
-	log.Print("setting up scheme")
+	log.Info("registering components")
+
+	log.Info("setting up scheme")
 	if err := apis.AddToScheme(mgr.GetScheme()); err != nil {
-		log.Printf("unable to add APIs to scheme: %v", err)
+		log.Error(err, "unable to add APIs to scheme")
 	}
 
 	if err := apiextenstionsv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
-		log.Printf("unable to add extension APIs to scheme: %v", err)
+		log.Error(err, "unable to add extension APIs to scheme")
 	}
 
 	// Setup all Controllers
 
-	log.Print("Setting up operator controller")
+	log.Info("setting up field indexes")
+	if err := controller.AddIndexes(mgr.GetFieldIndexer()); err != nil {
+		log.Error(err, "unable to add field indexes to the manager")
+		os.Exit(1)
+	}
+
+	log.Info("setting up operator controller")
 	err = (&operator.Reconciler{
 		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("operator"),
+
+		MaxConcurrentReconciles: cfg.MaxConcurrentReconciles,
 	}).SetupWithManager(mgr)
 	if err != nil {
-		log.Printf("unable to register operator controller to the manager: %v", err)
+		log.Error(err, "unable to register operator controller to the manager")
 		os.Exit(1)
 	}
 
-	log.Print("Setting up operator version controller")
+	log.Info("setting up operator version controller")
 	err = (&operatorversion.Reconciler{
 		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("operatorversion"),
+
+		MaxConcurrentReconciles: cfg.MaxConcurrentReconciles,
 	}).SetupWithManager(mgr)
 	if err != nil {
-		log.Printf("unable to register operator controller to the manager: %v", err)
+		log.Error(err, "unable to register operator version controller to the manager")
 		os.Exit(1)
 	}
 
-	log.Print("Setting up instance controller")
+	log.Info("setting up instance controller")
 	err = (&instance.Reconciler{
-		Client:   mgr.GetClient(),
-		Recorder: mgr.GetEventRecorderFor("instance-controller"),
-		Scheme:   mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Discovery: discovery.NewDiscoveryClientForConfigOrDie(mgr.GetConfig()),
+		Recorder:  mgr.GetEventRecorderFor("instance-controller"),
+		Scheme:    mgr.GetScheme(),
+		Log:       ctrl.Log.WithName("controllers").WithName("instance"),
+
+		RegistryMirrors:         cfg.RegistryMirrors,
+		WatchNamespaces:         cfg.WatchNamespaces,
+		MaxConcurrentReconciles: cfg.MaxConcurrentReconciles,
 	}).SetupWithManager(mgr)
 	if err != nil {
-		log.Printf("unable to register instance controller to the manager: %v", err)
+		log.Error(err, "unable to register instance controller to the manager")
 		os.Exit(1)
 	}
 
-	if strings.ToLower(os.Getenv("ENABLE_WEBHOOKS")) == "true" {
-		log.Printf("Setting up webhooks")
+	if cfg.Webhook.Enabled {
+		log.Info("setting up webhooks")
 
-		if err := registerWebhook("/validate", &v1beta1.Instance{}, &webhook.Admission{Handler: &v1beta1.InstanceValidator{}}, mgr); err != nil {
-			log.Printf("unable to create instance validation webhook: %v", err)
+		if err := registerWebhook("/validate", &v1beta1.Instance{}, &webhook.Admission{Handler: &v1beta1.InstanceValidator{Namespaces: cfg.WatchNamespaces}}, mgr); err != nil {
+			log.Error(err, "unable to create instance validation webhook")
 			os.Exit(1)
 		}
 
@@ -134,9 +204,9 @@ func main() {
 	}
 
 	// Start the KUDO manager
-	log.Print("Starting KUDO manager")
+	log.Info("starting KUDO manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		log.Printf("unable to run the manager: %v", err)
+		log.Error(err, "unable to run the manager")
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,185 @@
+// Package v1beta1 contains the v1beta1 version of the KUDO manager configuration file
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// GroupName is the group of the manager configuration
+	GroupName = "config.kudo.dev"
+	// Kind is the kind of the manager configuration
+	Kind = "ManagerConfiguration"
+)
+
+// APIVersion is the API version of this version of the manager configuration
+var APIVersion = fmt.Sprintf("%s/v1beta1", GroupName)
+
+// Defaults of the manager configuration
+const (
+	DefaultMetricsBindAddress     = ":8080"
+	DefaultHealthProbeBindAddress = ":8081"
+	DefaultWebhookPort            = 443
+	DefaultWebhookCertDir         = "/tmp/cert"
+	DefaultLeaderElectionID       = "kudo-controller-manager-leader"
+	DefaultLogLevel               = "info"
+	DefaultLogFormat              = LogFormatJSON
+)
+
+// Log formats of the manager
+const (
+	LogFormatJSON    = "json"
+	LogFormatConsole = "console"
+)
+
+// ManagerConfiguration configures the KUDO manager. It is read from a YAML file, settings missing in the file keep
+// their defaults.
+type ManagerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// SyncPeriod is the minimum frequency at which watched resources are reconciled, defaults to the
+	// controller-runtime default of 10 hours
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles of each controller, defaults to 1
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+	// MetricsBindAddress is the address the Prometheus metrics are served at, "0" disables the metrics endpoint
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
+	// HealthProbeBindAddress is the address the /healthz and /readyz endpoints are served at, "0" disables them
+	HealthProbeBindAddress string `json:"healthProbeBindAddress,omitempty"`
+	// WatchNamespaces limits the manager to the listed namespaces, all namespaces are watched if empty
+	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+	// RegistryMirrors maps container image registries to their mirrors, e.g. "docker.io" to "mirror.corp/dockerhub"
+	RegistryMirrors map[string]string `json:"registryMirrors,omitempty"`
+
+	Webhook        WebhookConfiguration        `json:"webhook"`
+	LeaderElection LeaderElectionConfiguration `json:"leaderElection"`
+	Logging        LoggingConfiguration        `json:"logging"`
+}
+
+// WebhookConfiguration configures the admission webhook server of the manager
+type WebhookConfiguration struct {
+	// Enabled enables the admission webhooks
+	Enabled bool `json:"enabled"`
+	// Port is the port the webhook server listens on
+	Port int `json:"port,omitempty"`
+	// CertDir contains the tls.crt and tls.key of the webhook server
+	CertDir string `json:"certDir,omitempty"`
+}
+
+// LeaderElectionConfiguration configures the leader election between manager replicas. Only the leader runs the
+// controllers.
+type LeaderElectionConfiguration struct {
+	// Enabled enables leader election
+	Enabled bool `json:"enabled"`
+	// Namespace holds the leader election lock, it defaults to the namespace the manager runs in
+	Namespace string `json:"namespace,omitempty"`
+	// ID is the name of the config map holding the lock
+	ID string `json:"id,omitempty"`
+}
+
+// LoggingConfiguration configures the logger of the manager
+type LoggingConfiguration struct {
+	// Level is the minimum level of logged messages: debug, info or error
+	Level string `json:"level,omitempty"`
+	// Format is the format of logged messages, json or console
+	Format string `json:"format,omitempty"`
+}
+
+// NewManagerConfiguration returns a manager configuration with all defaults set
+func NewManagerConfiguration() *ManagerConfiguration {
+	return &ManagerConfiguration{
+		TypeMeta:                metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind},
+		MaxConcurrentReconciles: 1,
+		MetricsBindAddress:      DefaultMetricsBindAddress,
+		HealthProbeBindAddress:  DefaultHealthProbeBindAddress,
+		Webhook: WebhookConfiguration{
+			Port:    DefaultWebhookPort,
+			CertDir: DefaultWebhookCertDir,
+		},
+		LeaderElection: LeaderElectionConfiguration{
+			ID: DefaultLeaderElectionID,
+		},
+		Logging: LoggingConfiguration{
+			Level:  DefaultLogLevel,
+			Format: DefaultLogFormat,
+		},
+	}
+}
+
+// Load reads a YAML configuration file into the configuration. Only settings present in the file are overridden,
+// unknown settings and other versions of the configuration are rejected.
+func (c *ManagerConfiguration) Load(data []byte) error {
+	loaded := c.DeepCopy()
+	loaded.TypeMeta = metav1.TypeMeta{}
+	if err := yaml.UnmarshalStrict(data, loaded); err != nil {
+		return fmt.Errorf("failed to parse manager configuration: %v", err)
+	}
+	if loaded.APIVersion != APIVersion || loaded.Kind != Kind {
+		return fmt.Errorf("unsupported manager configuration %s %s, expected %s %s", loaded.APIVersion, loaded.Kind, APIVersion, Kind)
+	}
+	*c = *loaded
+	return nil
+}
+
+// Validate checks that the configuration can be used to start the manager
+func (c *ManagerConfiguration) Validate() error {
+	if c.SyncPeriod != nil && c.SyncPeriod.Duration <= 0 {
+		return fmt.Errorf("sync period must be positive, got %v", c.SyncPeriod.Duration)
+	}
+	if c.MaxConcurrentReconciles < 1 {
+		return fmt.Errorf("max concurrent reconciles must be at least 1, got %d", c.MaxConcurrentReconciles)
+	}
+	if c.Webhook.Enabled && (c.Webhook.Port < 1 || c.Webhook.Port > 65535) {
+		return fmt.Errorf("invalid webhook port %d", c.Webhook.Port)
+	}
+	if c.Webhook.Enabled && c.Webhook.CertDir == "" {
+		return errors.New("webhook cert dir must be set when webhooks are enabled")
+	}
+	if c.LeaderElection.Enabled && c.LeaderElection.ID == "" {
+		return errors.New("leader election id must be set when leader election is enabled")
+	}
+	for registry, mirror := range c.RegistryMirrors {
+		if registry == "" || mirror == "" {
+			return fmt.Errorf("invalid registry mirror %q=%q", registry, mirror)
+		}
+	}
+	switch c.Logging.Format {
+	case LogFormatJSON, LogFormatConsole:
+	default:
+		return fmt.Errorf("invalid log format %q, supported are %s and %s", c.Logging.Format, LogFormatJSON, LogFormatConsole)
+	}
+	return nil
+}
+
+// SyncPeriodDuration returns the sync period or nil if the controller-runtime default is used
+func (c *ManagerConfiguration) SyncPeriodDuration() *time.Duration {
+	if c.SyncPeriod == nil {
+		return nil
+	}
+	d := c.SyncPeriod.Duration
+	return &d
+}
+
+// DeepCopy returns a copy of the configuration
+func (c *ManagerConfiguration) DeepCopy() *ManagerConfiguration {
+	out := *c
+	if c.SyncPeriod != nil {
+		d := *c.SyncPeriod
+		out.SyncPeriod = &d
+	}
+	if c.WatchNamespaces != nil {
+		out.WatchNamespaces = append([]string{}, c.WatchNamespaces...)
+	}
+	if c.RegistryMirrors != nil {
+		out.RegistryMirrors = make(map[string]string, len(c.RegistryMirrors))
+		for k, v := range c.RegistryMirrors {
+			out.RegistryMirrors[k] = v
+		}
+	}
+	return &out
+}
@@ -0,0 +1,107 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestManagerConfiguration_Load(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected func(*ManagerConfiguration)
+		err      string
+	}{
+		{
+			name:     "empty configuration keeps defaults",
+			data:     "apiVersion: config.kudo.dev/v1beta1\nkind: ManagerConfiguration\n",
+			expected: func(*ManagerConfiguration) {},
+		},
+		{
+			name: "settings override defaults",
+			data: `apiVersion: config.kudo.dev/v1beta1
+kind: ManagerConfiguration
+syncPeriod: 1h
+maxConcurrentReconciles: 3
+watchNamespaces: [team-a]
+webhook:
+  enabled: true
+logging:
+  format: console
+`,
+			expected: func(c *ManagerConfiguration) {
+				c.SyncPeriod = &metav1.Duration{Duration: time.Hour}
+				c.MaxConcurrentReconciles = 3
+				c.WatchNamespaces = []string{"team-a"}
+				c.Webhook.Enabled = true
+				c.Logging.Format = LogFormatConsole
+			},
+		},
+		{
+			name: "unknown setting",
+			data: "apiVersion: config.kudo.dev/v1beta1\nkind: ManagerConfiguration\nfoo: bar\n",
+			err:  `failed to parse manager configuration: error unmarshaling JSON: while decoding JSON: json: unknown field "foo"`,
+		},
+		{
+			name: "other version",
+			data: "apiVersion: config.kudo.dev/v1alpha1\nkind: ManagerConfiguration\n",
+			err:  "unsupported manager configuration config.kudo.dev/v1alpha1 ManagerConfiguration, expected config.kudo.dev/v1beta1 ManagerConfiguration",
+		},
+		{
+			name: "missing kind",
+			data: "maxConcurrentReconciles: 3\n",
+			err:  "unsupported manager configuration  , expected config.kudo.dev/v1beta1 ManagerConfiguration",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewManagerConfiguration()
+			err := cfg.Load([]byte(tt.data))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Equal(t, NewManagerConfiguration(), cfg, "configuration must not change on errors")
+				return
+			}
+			assert.NoError(t, err)
+
+			expected := NewManagerConfiguration()
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestManagerConfiguration_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*ManagerConfiguration)
+		err    string
+	}{
+		{name: "defaults", modify: func(*ManagerConfiguration) {}},
+		{name: "negative sync period", modify: func(c *ManagerConfiguration) { c.SyncPeriod = &metav1.Duration{Duration: -time.Second} }, err: "sync period must be positive, got -1s"},
+		{name: "no concurrent reconciles", modify: func(c *ManagerConfiguration) { c.MaxConcurrentReconciles = 0 }, err: "max concurrent reconciles must be at least 1, got 0"},
+		{name: "invalid webhook port", modify: func(c *ManagerConfiguration) { c.Webhook.Enabled = true; c.Webhook.Port = 0 }, err: "invalid webhook port 0"},
+		{name: "disabled webhook port", modify: func(c *ManagerConfiguration) { c.Webhook.Port = 0 }},
+		{name: "missing leader election id", modify: func(c *ManagerConfiguration) { c.LeaderElection.Enabled = true; c.LeaderElection.ID = "" }, err: "leader election id must be set when leader election is enabled"},
+		{name: "invalid log format", modify: func(c *ManagerConfiguration) { c.Logging.Format = "text" }, err: `invalid log format "text", supported are json and console`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewManagerConfiguration()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition describes one aspect of the state of a KUDO resource. It follows the Kubernetes API conventions for
+// conditions, so that generic tools like 'kubectl wait --for=condition=Ready' can use it.
+type Condition struct {
+	// Type of the condition in CamelCase, e.g. Ready
+	Type string `json:"type"`
+	// Status of the condition, one of True, False or Unknown
+	Status metav1.ConditionStatus `json:"status"`
+	// ObservedGeneration is the metadata.generation of the resource the condition was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastTransitionTime is the last time the status of the condition changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Reason is a CamelCase identifier of the cause of the last status change
+	Reason string `json:"reason"`
+	// Message is a human readable explanation of the status
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SetCondition adds or replaces the condition of the same type in the list of conditions. The last transition time
+// is only changed if the status of the condition changes.
+func SetCondition(conditions *[]Condition, condition Condition) {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	for i, c := range *conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+		if c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+		(*conditions)[i] = condition
+		return
+	}
+	*conditions = append(*conditions, condition)
+}
+
+// FindCondition returns the condition of the given type or nil if the list doesn't contain it
+func FindCondition(conditions []Condition, conditionType string) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true if the list contains a condition of the given type with status True
+func IsConditionTrue(conditions []Condition, conditionType string) bool {
+	c := FindCondition(conditions, conditionType)
+	return c != nil && c.Status == metav1.ConditionTrue
+}
@@ -18,18 +18,24 @@ package v1beta1
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
 	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
-const instanceCleanupFinalizerName = "kudo.dev.instance.cleanup"
+const (
+	instanceCleanupFinalizerName   = "kudo.dev.instance.cleanup"
+	instanceResourcesFinalizerName = "kudo.dev.instance.resources"
+)
 
 // InstanceSpec defines the desired state of Instance.
 type InstanceSpec struct {
@@ -37,6 +43,68 @@ type InstanceSpec struct {
 	OperatorVersion corev1.ObjectReference `json:"operatorVersion,omitempty"`
 
 	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Customization of the resources rendered from the operator templates, applied on top of the KUDO conventions.
+	// Changing it triggers the update plan, or the deploy plan if there is no update plan.
+	// +optional
+	Customization *InstanceCustomization `json:"customization,omitempty"`
+}
+
+// InstanceCustomization allows instance owners to customize the rendered resources of an operator without forking it.
+// All fields are applied using kustomize.
+type InstanceCustomization struct {
+	// CommonLabels are added to all resources. As with kustomize common labels, they are added to selectors too,
+	// so changing them later might require resources with immutable selectors to be recreated.
+	// KUDO labels can not be overridden.
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// CommonAnnotations are added to all resources. KUDO annotations can not be overridden.
+	// +optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// PatchesStrategicMerge is a list of strategic merge patches. Each patch is a YAML document identifying its
+	// target resource by apiVersion, kind and metadata.name, e.g. to add tolerations to a Deployment.
+	// +optional
+	PatchesStrategicMerge []string `json:"patchesStrategicMerge,omitempty"`
+
+	// PatchesJSON6902 is a list of JSON patches (RFC 6902) applied to their target resource.
+	// +optional
+	PatchesJSON6902 []JSON6902Patch `json:"patchesJson6902,omitempty"`
+
+	// Images overrides the name, tag or digest of container images used by the rendered resources. Overrides are
+	// applied before the registry mirrors configured for the KUDO manager.
+	// +optional
+	Images []ImageOverride `json:"images,omitempty"`
+}
+
+// ImageOverride replaces a container image referenced by the operator templates
+type ImageOverride struct {
+	// Name is the image name as used in the templates, without tag or digest, e.g. "nginx" or "quay.io/org/app"
+	Name string `json:"name"`
+	// +optional
+	NewName string `json:"newName,omitempty"`
+	// +optional
+	NewTag string `json:"newTag,omitempty"`
+	// Digest replaces the image tag, NewTag is ignored if set
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// JSON6902Patch is a JSON patch with the resource it is applied to
+type JSON6902Patch struct {
+	Target PatchTarget `json:"target"`
+	// Patch is the list of JSON patch operations in JSON or YAML format
+	Patch string `json:"patch"`
+}
+
+// PatchTarget identifies a rendered resource by its group, version, kind and name
+type PatchTarget struct {
+	// +optional
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
 }
 
 // InstanceStatus defines the observed state of Instance
@@ -44,8 +112,36 @@ type InstanceStatus struct {
 	// slice would be enough here but we cannot use slice because order of sequence in yaml is considered significant while here it's not
 	PlanStatus       map[string]PlanStatus `json:"planStatus,omitempty"`
 	AggregatedStatus AggregatedStatus      `json:"aggregatedStatus,omitempty"`
+
+	// Conditions are the Ready, Progressing, Degraded and PlanFailed conditions derived from the plan status
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
+// Condition types of an Instance
+const (
+	// InstanceConditionReady is true if the last executed plan completed and no plan is in progress
+	InstanceConditionReady = "Ready"
+	// InstanceConditionProgressing is true while a plan is executed
+	InstanceConditionProgressing = "Progressing"
+	// InstanceConditionDegraded is true if the active plan runs into errors or the last plan failed
+	InstanceConditionDegraded = "Degraded"
+	// InstanceConditionPlanFailed is true if the last executed plan failed with a fatal error
+	InstanceConditionPlanFailed = "PlanFailed"
+)
+
+// Reasons of the Instance conditions
+const (
+	InstanceReasonNoPlanExecuted = "NoPlanExecuted"
+	InstanceReasonPlanInProgress = "PlanInProgress"
+	InstanceReasonPlanError      = "PlanError"
+	InstanceReasonPlanComplete   = "PlanComplete"
+	InstanceReasonPlanFatalError = "PlanFatalError"
+
+	InstanceReasonOperatorVersionNotVerified = "OperatorVersionNotVerified"
+	InstanceReasonInvalidOperatorVersion     = "InvalidOperatorVersion"
+)
+
 // AggregatedStatus is overview of an instance status derived from the plan status
 type AggregatedStatus struct {
 	Status         ExecutionStatus `json:"status,omitempty"`
@@ -349,6 +445,82 @@ func (i *Instance) UpdateInstanceStatus(planStatus *PlanStatus) {
 	}
 }
 
+// UpdateConditions sets the conditions of the instance based on the status of the active or last executed plan. All
+// conditions carry the current generation of the instance, so this must only be used once the current spec was acted
+// on, i.e. its plan was started or no plan is needed for it. See SetPendingPlanConditions otherwise.
+func (i *Instance) UpdateConditions() {
+	set := func(conditionType string, status bool, reason, message string) {
+		c := Condition{Type: conditionType, Status: metav1.ConditionFalse, ObservedGeneration: i.Generation, Reason: reason, Message: message}
+		if status {
+			c.Status = metav1.ConditionTrue
+		}
+		SetCondition(&i.Status.Conditions, c)
+	}
+
+	plan := i.GetLastExecutedPlanStatus()
+	if plan == nil {
+		message := "no plan was executed yet"
+		set(InstanceConditionReady, false, InstanceReasonNoPlanExecuted, message)
+		set(InstanceConditionProgressing, false, InstanceReasonNoPlanExecuted, message)
+		set(InstanceConditionDegraded, false, InstanceReasonNoPlanExecuted, message)
+		set(InstanceConditionPlanFailed, false, InstanceReasonNoPlanExecuted, message)
+		return
+	}
+
+	switch plan.Status {
+	case ExecutionComplete:
+		message := fmt.Sprintf("plan %s completed", plan.Name)
+		set(InstanceConditionReady, true, InstanceReasonPlanComplete, message)
+		set(InstanceConditionProgressing, false, InstanceReasonPlanComplete, message)
+		set(InstanceConditionDegraded, false, InstanceReasonPlanComplete, message)
+		set(InstanceConditionPlanFailed, false, InstanceReasonPlanComplete, message)
+	case ExecutionFatalError:
+		message := fmt.Sprintf("plan %s failed", plan.Name)
+		if plan.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, plan.Message)
+		}
+		set(InstanceConditionReady, false, InstanceReasonPlanFatalError, message)
+		set(InstanceConditionProgressing, false, InstanceReasonPlanFatalError, message)
+		set(InstanceConditionDegraded, true, InstanceReasonPlanFatalError, message)
+		set(InstanceConditionPlanFailed, true, InstanceReasonPlanFatalError, message)
+	default:
+		message := fmt.Sprintf("plan %s is in progress", plan.Name)
+		set(InstanceConditionReady, false, InstanceReasonPlanInProgress, message)
+		set(InstanceConditionProgressing, true, InstanceReasonPlanInProgress, message)
+		if plan.Status == ErrorStatus {
+			errMessage := fmt.Sprintf("plan %s has errors", plan.Name)
+			if plan.Message != "" {
+				errMessage = fmt.Sprintf("%s: %s", errMessage, plan.Message)
+			}
+			set(InstanceConditionDegraded, true, InstanceReasonPlanError, errMessage)
+		} else {
+			set(InstanceConditionDegraded, false, InstanceReasonPlanInProgress, message)
+		}
+		set(InstanceConditionPlanFailed, false, InstanceReasonPlanInProgress, message)
+	}
+}
+
+// SetPendingPlanConditions sets the conditions of an instance whose spec requires a plan that can't be started, with
+// the given reason. Ready is false and either Progressing is true, while the plan waits to be started, or Degraded is
+// true, if the plan is refused. The conditions keep the generation observed before, as the current spec wasn't acted on.
+func (i *Instance) SetPendingPlanConditions(reason, message string, refused bool) {
+	var observedGeneration int64
+	if ready := FindCondition(i.Status.Conditions, InstanceConditionReady); ready != nil {
+		observedGeneration = ready.ObservedGeneration
+	}
+	set := func(conditionType string, status bool) {
+		c := Condition{Type: conditionType, Status: metav1.ConditionFalse, ObservedGeneration: observedGeneration, Reason: reason, Message: message}
+		if status {
+			c.Status = metav1.ConditionTrue
+		}
+		SetCondition(&i.Status.Conditions, c)
+	}
+
+	set(InstanceConditionReady, false)
+	set(InstanceConditionProgressing, !refused)
+	set(InstanceConditionDegraded, refused)
+}
+
 const snapshotAnnotation = "kudo.dev/last-applied-instance-state"
 
 // SaveSnapshot stores the current spec of Instance into the snapshot annotation
@@ -400,8 +572,9 @@ func (i *Instance) PlanStatus(plan string) *PlanStatus {
 	return nil
 }
 
-// GetPlanToBeExecuted returns name of the plan that should be executed
-func (i *Instance) GetPlanToBeExecuted(ov *OperatorVersion) (*string, error) {
+// GetPlanToBeExecuted returns name of the plan that should be executed. The reason for executing a plan because the
+// instance changed is logged to the passed logger, which is expected to carry the instance context.
+func (i *Instance) GetPlanToBeExecuted(ov *OperatorVersion, log logr.Logger) (*string, error) {
 	if i.IsDeleting() {
 		// we have a cleanup plan
 		plan := selectPlan([]string{CleanupPlanName}, ov)
@@ -438,7 +611,7 @@ func (i *Instance) GetPlanToBeExecuted(ov *OperatorVersion) (*string, error) {
 	}
 	if instanceSnapshot.OperatorVersion.Name != i.Spec.OperatorVersion.Name {
 		// this instance was upgraded to newer version
-		log.Printf("Instance: instance %s/%s was upgraded from %s to %s operatorVersion", i.Namespace, i.Name, instanceSnapshot.OperatorVersion.Name, i.Spec.OperatorVersion.Name)
+		log.Info("instance was upgraded", "fromOperatorVersion", instanceSnapshot.OperatorVersion.Name, "toOperatorVersion", i.Spec.OperatorVersion.Name)
 		plan := selectPlan([]string{UpgradePlanName, UpdatePlanName, DeployPlanName}, ov)
 		if plan == nil {
 			return nil, &InstanceError{fmt.Errorf("supposed to execute plan because instance %s/%s was upgraded but none of the deploy, upgrade, update plans found in linked operatorVersion", i.Namespace, i.Name), kudo.String("PlanNotFound")}
@@ -448,7 +621,7 @@ func (i *Instance) GetPlanToBeExecuted(ov *OperatorVersion) (*string, error) {
 	// did instance parameters change, so that the corresponding plan has to be triggered?
 	if !reflect.DeepEqual(instanceSnapshot.Parameters, i.Spec.Parameters) {
 		// instance updated
-		log.Printf("Instance: instance %s/%s has updated parameters from %v to %v", i.Namespace, i.Name, instanceSnapshot.Parameters, i.Spec.Parameters)
+		log.Info("instance parameters were updated", "fromParameters", instanceSnapshot.Parameters, "toParameters", i.Spec.Parameters)
 		paramDiff := parameterDiff(instanceSnapshot.Parameters, i.Spec.Parameters)
 		paramDefinitions := getParamDefinitions(paramDiff, ov)
 		plan := planNameFromParameters(paramDefinitions, ov)
@@ -457,6 +630,15 @@ func (i *Instance) GetPlanToBeExecuted(ov *OperatorVersion) (*string, error) {
 		}
 		return plan, nil
 	}
+	// did the customization change, so that the rendered resources have to be updated?
+	if !reflect.DeepEqual(instanceSnapshot.Customization, i.Spec.Customization) {
+		log.Info("instance customization was updated")
+		plan := selectPlan([]string{UpdatePlanName, DeployPlanName}, ov)
+		if plan == nil {
+			return nil, &InstanceError{fmt.Errorf("supposed to execute plan because the customization of instance %s/%s was updated but none of the deploy, update plans found in linked operatorVersion", i.Namespace, i.Name), kudo.String("PlanNotFound")}
+		}
+		return plan, nil
+	}
 	return nil, nil
 }
 
@@ -471,6 +653,13 @@ func planNameFromParameters(params []Parameter, ov *OperatorVersion) *string {
 	return selectPlan([]string{UpdatePlanName, DeployPlanName}, ov)
 }
 
+// TriggeredPlan returns the name of the plan a change of the parameter triggers: its trigger plan or, for parameters
+// without an existing trigger plan, the update plan falling back to the deploy plan. Nil is returned if none of these
+// plans exist.
+func (p Parameter) TriggeredPlan(ov *OperatorVersion) *string {
+	return planNameFromParameters([]Parameter{p}, ov)
+}
+
 // getParamDefinitions retrieves parameter metadata from OperatorVersion CRD
 func getParamDefinitions(params map[string]string, ov *OperatorVersion) []Parameter {
 	defs := []Parameter{}
@@ -563,6 +752,80 @@ func (i *Instance) TryRemoveFinalizer() bool {
 	return false
 }
 
+// HasCleanupFinalizer returns true if the instance has the cleanup finalizer i.e. it is still waiting for its
+// cleanup plan to finish.
+func (i *Instance) HasCleanupFinalizer() bool {
+	return contains(i.ObjectMeta.Finalizers, instanceCleanupFinalizerName)
+}
+
+// TrackUnownedResources records the kind of a resource which can not be owned by the instance (a cluster-scoped
+// resource or a resource in another namespace) in the kudo.UnownedResourcesAnnotation and adds the resources finalizer.
+// This finalizer makes sure that the resources of the recorded kinds are deleted along with the instance. Kubernetes
+// doesn't allow to add finalizers to an instance that is already being deleted, in that case only the kind is
+// recorded. Returns true if the instance has been changed.
+func (i *Instance) TrackUnownedResources(gvk schema.GroupVersionKind) bool {
+	changed := false
+	if !i.IsDeleting() && !contains(i.ObjectMeta.Finalizers, instanceResourcesFinalizerName) {
+		i.ObjectMeta.Finalizers = append(i.ObjectMeta.Finalizers, instanceResourcesFinalizerName)
+		changed = true
+	}
+
+	kind := fmt.Sprintf("%s/%s", gvk.GroupVersion().String(), gvk.Kind)
+	kinds := []string{}
+	if v := i.ObjectMeta.Annotations[kudo.UnownedResourcesAnnotation]; v != "" {
+		kinds = strings.Split(v, ",")
+	}
+	if contains(kinds, kind) {
+		return changed
+	}
+
+	kinds = append(kinds, kind)
+	sort.Strings(kinds)
+	if i.ObjectMeta.Annotations == nil {
+		i.ObjectMeta.Annotations = map[string]string{}
+	}
+	i.ObjectMeta.Annotations[kudo.UnownedResourcesAnnotation] = strings.Join(kinds, ",")
+	return true
+}
+
+// UnownedResourceKinds returns the kinds of the resources which can not be owned by the instance, recorded by
+// TrackUnownedResources. Malformed values of the kudo.UnownedResourcesAnnotation are skipped.
+func (i *Instance) UnownedResourceKinds() []schema.GroupVersionKind {
+	v := i.ObjectMeta.Annotations[kudo.UnownedResourcesAnnotation]
+	if v == "" {
+		return nil
+	}
+
+	kinds := make([]schema.GroupVersionKind, 0)
+	for _, kind := range strings.Split(v, ",") {
+		sep := strings.LastIndex(kind, "/")
+		if sep <= 0 {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(kind[:sep])
+		if err != nil {
+			continue
+		}
+		kinds = append(kinds, gv.WithKind(kind[sep+1:]))
+	}
+	return kinds
+}
+
+// HasResourcesFinalizer returns true if the instance has the resources finalizer.
+func (i *Instance) HasResourcesFinalizer() bool {
+	return contains(i.ObjectMeta.Finalizers, instanceResourcesFinalizerName)
+}
+
+// RemoveResourcesFinalizer removes the resources finalizer of an instance. Returns true if the finalizer has been removed.
+func (i *Instance) RemoveResourcesFinalizer() bool {
+	if contains(i.ObjectMeta.Finalizers, instanceResourcesFinalizerName) {
+		i.ObjectMeta.Finalizers = remove(i.ObjectMeta.Finalizers, instanceResourcesFinalizerName)
+		return true
+	}
+
+	return false
+}
+
 // IsDeleting returns true is the instance is being deleted.
 func (i *Instance) IsDeleting() bool {
 	// a delete request is indicated by a non-zero 'metadata.deletionTimestamp',
@@ -592,6 +855,15 @@ func (i *Instance) OperatorVersionNamespace() string {
 	return i.Spec.OperatorVersion.Namespace
 }
 
+// OperatorVersionNamespacedName returns the namespace and name of the OperatorVersion that the Instance references.
+// Its string representation (namespace/name) is the value of the Instance in the InstanceOperatorVersionIndex.
+func (i *Instance) OperatorVersionNamespacedName() apimachinerytypes.NamespacedName {
+	return apimachinerytypes.NamespacedName{Namespace: i.OperatorVersionNamespace(), Name: i.Spec.OperatorVersion.Name}
+}
+
+// InstanceOperatorVersionIndex is the name of the cache field index of Instances by their OperatorVersion
+const InstanceOperatorVersionIndex = "spec.operatorVersion"
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // InstanceList contains a list of Instance.
@@ -21,6 +21,10 @@ import (
 
 	"github.com/onsi/gomega"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
 func TestGetLastExecutedPlanStatus(t *testing.T) {
@@ -99,3 +103,164 @@ func TestSpecParameterDifference(t *testing.T) {
 		g.Expect(diff).Should(gomega.Equal(test.diff), test.name)
 	}
 }
+
+func TestOperatorVersionNamespacedName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	i := &Instance{ObjectMeta: v1.ObjectMeta{Name: "zk", Namespace: "team-a"}}
+	i.Spec.OperatorVersion.Name = "zookeeper-0.3.0"
+	g.Expect(i.OperatorVersionNamespacedName().String()).Should(gomega.Equal("team-a/zookeeper-0.3.0"))
+
+	i.Spec.OperatorVersion.Namespace = "shared"
+	g.Expect(i.OperatorVersionNamespacedName().String()).Should(gomega.Equal("shared/zookeeper-0.3.0"))
+}
+
+func TestGetPlanToBeExecuted_Customization(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	ov := &OperatorVersion{Spec: OperatorVersionSpec{Plans: map[string]Plan{DeployPlanName: {}}}}
+	i := &Instance{}
+	i.Spec.Parameters = map[string]string{"REPLICAS": "3"}
+	i.Status.PlanStatus = map[string]PlanStatus{DeployPlanName: {Name: DeployPlanName, Status: ExecutionComplete}}
+	g.Expect(i.SaveSnapshot()).Should(gomega.Succeed())
+
+	plan, err := i.GetPlanToBeExecuted(ov, log.NullLogger{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(plan).Should(gomega.BeNil(), "nothing changed")
+
+	i.Spec.Customization = &InstanceCustomization{CommonLabels: map[string]string{"team": "a"}}
+	plan, err = i.GetPlanToBeExecuted(ov, log.NullLogger{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(plan).Should(gomega.Equal(kudo.String(DeployPlanName)), "deploy plan is used without update plan")
+
+	ov.Spec.Plans[UpdatePlanName] = Plan{}
+	plan, err = i.GetPlanToBeExecuted(ov, log.NullLogger{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(plan).Should(gomega.Equal(kudo.String(UpdatePlanName)))
+}
+
+func TestTrackUnownedResources(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	priorityClass := schema.GroupVersionKind{Group: "scheduling.k8s.io", Version: "v1", Kind: "PriorityClass"}
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	i := &Instance{}
+	g.Expect(i.UnownedResourceKinds()).Should(gomega.BeEmpty())
+	g.Expect(i.HasResourcesFinalizer()).Should(gomega.BeFalse())
+
+	g.Expect(i.TrackUnownedResources(priorityClass)).Should(gomega.BeTrue())
+	g.Expect(i.TrackUnownedResources(configMap)).Should(gomega.BeTrue())
+	g.Expect(i.TrackUnownedResources(priorityClass)).Should(gomega.BeFalse(), "kind is already tracked")
+	g.Expect(i.HasResourcesFinalizer()).Should(gomega.BeTrue())
+	g.Expect(i.Annotations[kudo.UnownedResourcesAnnotation]).Should(gomega.Equal("scheduling.k8s.io/v1/PriorityClass,v1/ConfigMap"))
+	g.Expect(i.UnownedResourceKinds()).Should(gomega.Equal([]schema.GroupVersionKind{priorityClass, configMap}))
+
+	deleting := &Instance{ObjectMeta: v1.ObjectMeta{DeletionTimestamp: &v1.Time{Time: time.Now()}}}
+	g.Expect(deleting.TrackUnownedResources(configMap)).Should(gomega.BeTrue())
+	g.Expect(deleting.HasResourcesFinalizer()).Should(gomega.BeFalse(), "finalizers can't be added to deleted objects")
+	g.Expect(deleting.UnownedResourceKinds()).Should(gomega.Equal([]schema.GroupVersionKind{configMap}))
+}
+
+func TestUpdateConditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     *PlanStatus
+		expected map[string]v1.ConditionStatus
+		reason   string
+		message  string
+	}{
+		{"no plan executed", nil,
+			map[string]v1.ConditionStatus{InstanceConditionReady: v1.ConditionFalse, InstanceConditionProgressing: v1.ConditionFalse, InstanceConditionDegraded: v1.ConditionFalse, InstanceConditionPlanFailed: v1.ConditionFalse},
+			InstanceReasonNoPlanExecuted, "no plan was executed yet"},
+		{"plan in progress", &PlanStatus{Name: "deploy", Status: ExecutionInProgress},
+			map[string]v1.ConditionStatus{InstanceConditionReady: v1.ConditionFalse, InstanceConditionProgressing: v1.ConditionTrue, InstanceConditionDegraded: v1.ConditionFalse, InstanceConditionPlanFailed: v1.ConditionFalse},
+			InstanceReasonPlanInProgress, "plan deploy is in progress"},
+		{"plan with errors", &PlanStatus{Name: "deploy", Status: ErrorStatus, Message: "timeout"},
+			map[string]v1.ConditionStatus{InstanceConditionReady: v1.ConditionFalse, InstanceConditionProgressing: v1.ConditionTrue, InstanceConditionDegraded: v1.ConditionTrue, InstanceConditionPlanFailed: v1.ConditionFalse},
+			InstanceReasonPlanInProgress, "plan deploy is in progress"},
+		{"plan complete", &PlanStatus{Name: "update", Status: ExecutionComplete},
+			map[string]v1.ConditionStatus{InstanceConditionReady: v1.ConditionTrue, InstanceConditionProgressing: v1.ConditionFalse, InstanceConditionDegraded: v1.ConditionFalse, InstanceConditionPlanFailed: v1.ConditionFalse},
+			InstanceReasonPlanComplete, "plan update completed"},
+		{"plan failed", &PlanStatus{Name: "deploy", Status: ExecutionFatalError, Message: "invalid template"},
+			map[string]v1.ConditionStatus{InstanceConditionReady: v1.ConditionFalse, InstanceConditionProgressing: v1.ConditionFalse, InstanceConditionDegraded: v1.ConditionTrue, InstanceConditionPlanFailed: v1.ConditionTrue},
+			InstanceReasonPlanFatalError, "plan deploy failed: invalid template"},
+	}
+
+	g := gomega.NewGomegaWithT(t)
+
+	for _, tt := range tests {
+		i := Instance{ObjectMeta: v1.ObjectMeta{Generation: 3}}
+		i.Status.PlanStatus = map[string]PlanStatus{"deploy": {Name: "deploy", Status: ExecutionNeverRun}}
+		if tt.plan != nil {
+			i.Status.PlanStatus[tt.plan.Name] = *tt.plan
+		}
+		i.UpdateConditions()
+
+		g.Expect(i.Status.Conditions).Should(gomega.HaveLen(len(tt.expected)), tt.name)
+		for conditionType, status := range tt.expected {
+			c := FindCondition(i.Status.Conditions, conditionType)
+			g.Expect(c).ShouldNot(gomega.BeNil(), tt.name)
+			g.Expect(c.Status).Should(gomega.Equal(status), "%s: condition %s", tt.name, conditionType)
+			g.Expect(c.ObservedGeneration).Should(gomega.Equal(int64(3)), tt.name)
+		}
+		ready := FindCondition(i.Status.Conditions, InstanceConditionReady)
+		g.Expect(ready.Reason).Should(gomega.Equal(tt.reason), tt.name)
+		g.Expect(ready.Message).Should(gomega.Equal(tt.message), tt.name)
+	}
+
+	i := Instance{}
+	i.Status.PlanStatus = map[string]PlanStatus{"deploy": {Name: "deploy", Status: ErrorStatus, Message: "timeout"}}
+	i.UpdateConditions()
+	degraded := FindCondition(i.Status.Conditions, InstanceConditionDegraded)
+	g.Expect(degraded.Reason).Should(gomega.Equal(InstanceReasonPlanError))
+	g.Expect(degraded.Message).Should(gomega.Equal("plan deploy has errors: timeout"))
+}
+
+func TestSetPendingPlanConditions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	i := Instance{ObjectMeta: v1.ObjectMeta{Generation: 2}}
+	i.Status.Conditions = []Condition{
+		{Type: InstanceConditionReady, Status: v1.ConditionTrue, Reason: InstanceReasonPlanComplete, ObservedGeneration: 1},
+		{Type: InstanceConditionPlanFailed, Status: v1.ConditionFalse, Reason: InstanceReasonPlanComplete, ObservedGeneration: 1},
+	}
+	i.SetPendingPlanConditions(InstanceReasonInvalidOperatorVersion, "plan deploy was refused", true)
+
+	expected := map[string]v1.ConditionStatus{InstanceConditionReady: v1.ConditionFalse, InstanceConditionProgressing: v1.ConditionFalse, InstanceConditionDegraded: v1.ConditionTrue}
+	for conditionType, status := range expected {
+		c := FindCondition(i.Status.Conditions, conditionType)
+		g.Expect(c).ShouldNot(gomega.BeNil(), conditionType)
+		g.Expect(c.Status).Should(gomega.Equal(status), conditionType)
+		g.Expect(c.Reason).Should(gomega.Equal(InstanceReasonInvalidOperatorVersion), conditionType)
+		g.Expect(c.ObservedGeneration).Should(gomega.Equal(int64(1)), "%s keeps the generation acted on", conditionType)
+	}
+	g.Expect(FindCondition(i.Status.Conditions, InstanceConditionPlanFailed).Reason).Should(gomega.Equal(InstanceReasonPlanComplete), "PlanFailed describes the last executed plan")
+
+	i = Instance{ObjectMeta: v1.ObjectMeta{Generation: 1}}
+	i.SetPendingPlanConditions(InstanceReasonOperatorVersionNotVerified, "plan deploy waits", false)
+	g.Expect(IsConditionTrue(i.Status.Conditions, InstanceConditionProgressing)).Should(gomega.BeTrue())
+	g.Expect(IsConditionTrue(i.Status.Conditions, InstanceConditionDegraded)).Should(gomega.BeFalse())
+	g.Expect(FindCondition(i.Status.Conditions, InstanceConditionReady).ObservedGeneration).Should(gomega.BeZero(), "no generation was acted on yet")
+}
+
+func TestSetCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	created := v1.NewTime(time.Date(2019, 10, 17, 1, 1, 1, 0, time.UTC))
+	conditions := []Condition{{Type: InstanceConditionReady, Status: v1.ConditionFalse, Reason: InstanceReasonPlanInProgress, LastTransitionTime: created}}
+
+	SetCondition(&conditions, Condition{Type: InstanceConditionReady, Status: v1.ConditionFalse, Reason: InstanceReasonPlanError, ObservedGeneration: 2})
+	g.Expect(conditions).Should(gomega.HaveLen(1))
+	g.Expect(conditions[0].Reason).Should(gomega.Equal(InstanceReasonPlanError))
+	g.Expect(conditions[0].ObservedGeneration).Should(gomega.Equal(int64(2)))
+	g.Expect(conditions[0].LastTransitionTime).Should(gomega.Equal(created), "unchanged status keeps the transition time")
+
+	SetCondition(&conditions, Condition{Type: InstanceConditionReady, Status: v1.ConditionTrue, Reason: InstanceReasonPlanComplete})
+	g.Expect(conditions[0].LastTransitionTime).ShouldNot(gomega.Equal(created), "changed status updates the transition time")
+	g.Expect(IsConditionTrue(conditions, InstanceConditionReady)).Should(gomega.BeTrue())
+
+	SetCondition(&conditions, Condition{Type: InstanceConditionProgressing, Status: v1.ConditionFalse, Reason: InstanceReasonPlanComplete})
+	g.Expect(conditions).Should(gomega.HaveLen(2))
+	g.Expect(IsConditionTrue(conditions, InstanceConditionProgressing)).Should(gomega.BeFalse())
+}
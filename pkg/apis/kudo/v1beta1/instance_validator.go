@@ -12,12 +12,19 @@ import (
 )
 
 type InstanceValidator struct {
+	// Namespaces limits validation to instances in the namespaces watched by the manager, instances of other
+	// namespaces are admitted. All instances are validated if empty.
+	Namespaces []string
+
 	client  client.Client
 	decoder *admission.Decoder
 }
 
 // InstanceValidator validates updates to an Instance, guarding from conflicting plan executions
 func (v *InstanceValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if !v.watches(req.Namespace) {
+		return admission.Allowed("")
+	}
 
 	switch req.Operation {
 	// we only validate Instance Updates
@@ -43,6 +50,19 @@ func (v *InstanceValidator) Handle(ctx context.Context, req admission.Request) a
 	}
 }
 
+// watches returns true if instances of the namespace are validated
+func (v *InstanceValidator) watches(namespace string) bool {
+	if len(v.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range v.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 func validateUpdate(old, new *Instance) error {
 	// Disallow spec updates when a plan is in progress
 	if old.Status.AggregatedStatus.Status.IsRunning() && specChanged(old.Spec, new.Spec) {
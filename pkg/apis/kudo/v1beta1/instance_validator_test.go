@@ -56,3 +56,11 @@ func TestValidateUpdate(t *testing.T) {
 		assert.Equal(t, tt.expectedError, err)
 	}
 }
+
+func TestInstanceValidator_Watches(t *testing.T) {
+	assert.True(t, (&InstanceValidator{}).watches("any"))
+
+	v := &InstanceValidator{Namespaces: []string{"team-a", "team-b"}}
+	assert.True(t, v.watches("team-b"))
+	assert.False(t, v.watches("team-c"))
+}
@@ -39,8 +39,27 @@ type Maintainer struct {
 
 // OperatorStatus defines the observed state of Operator
 type OperatorStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Versions are the OperatorVersions of the operator installed in its namespace, ordered by their semantic
+	// version with the latest version last
+	// +optional
+	Versions []InstalledOperatorVersion `json:"versions,omitempty"`
+	// LatestVersion is the highest installed version
+	// +optional
+	LatestVersion string `json:"latestVersion,omitempty"`
+	// Instances is the number of instances using any of the installed versions
+	Instances int `json:"instances"`
+	// OutdatedInstances is the number of instances not using the latest version
+	OutdatedInstances int `json:"outdatedInstances"`
+}
+
+// InstalledOperatorVersion summarizes an OperatorVersion of an operator and its usage
+type InstalledOperatorVersion struct {
+	// Name is the name of the OperatorVersion
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	AppVersion string `json:"appVersion,omitempty"`
+	// Instances is the number of instances using the OperatorVersion, including instances in other namespaces
+	Instances int `json:"instances"`
 }
 
 // +genclient
@@ -48,6 +67,11 @@ type OperatorStatus struct {
 
 // Operator is the Schema for the operator API
 // +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Latest",type="string",JSONPath=".status.latestVersion",description="The latest installed version"
+// +kubebuilder:printcolumn:name="Instances",type="integer",JSONPath=".status.instances",description="The number of instances of all versions"
+// +kubebuilder:printcolumn:name="Outdated",type="integer",JSONPath=".status.outdatedInstances",description="The number of instances not using the latest version"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Operator struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -121,8 +121,10 @@ type Task struct {
 // future should this become an issue.
 type TaskSpec struct {
 	ResourceTaskSpec `json:",inline"`
+	DeleteTaskSpec   `json:",inline"`
 	DummyTaskSpec    `json:",inline"`
 	PipeTaskSpec     `json:",inline"`
+	HelmTaskSpec     `json:",inline"`
 }
 
 // ResourceTaskSpec is referencing a list of resources
@@ -130,6 +132,29 @@ type ResourceTaskSpec struct {
 	Resources []string `json:"resources"`
 }
 
+// DeleteTaskSpec specifies how a Delete task removes resources
+type DeleteTaskSpec struct {
+	// PropagationPolicy is the deletion propagation policy: Foreground (default), Background or Orphan
+	// +optional
+	PropagationPolicy string `json:"propagationPolicy,omitempty"`
+	// Wait makes the task wait until all deleted objects are gone, including their finalizers
+	// +optional
+	Wait bool `json:"wait,omitempty"`
+	// Selector additionally deletes instance resources matching the selector
+	// +optional
+	Selector *DeleteSelector `json:"selector,omitempty"`
+}
+
+// DeleteSelector selects instance resources of the given kinds by labels. Only resources in the instance namespace
+// which belong to the instance are selected.
+type DeleteSelector struct {
+	// Kinds lists apiVersion and kind of the resources to delete
+	Kinds []metav1.TypeMeta `json:"kinds" validate:"required,gt=0"`
+	// MatchLabels is a map of labels the selected resources must have, in addition to the instance labels
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
 // DummyTaskSpec can succeed or fail on demand and is very useful for testing operators
 type DummyTaskSpec struct {
 	WantErr bool `json:"wantErr"`
@@ -137,6 +162,16 @@ type DummyTaskSpec struct {
 	Done    bool `json:"done"`
 }
 
+// HelmTaskSpec references a Helm chart embedded in the operator package (in templates/charts/<chart>) whose rendered
+// manifests are applied like the resources of an Apply task
+type HelmTaskSpec struct {
+	// Chart is the name of the chart directory in templates/charts
+	Chart string `json:"chart"`
+	// Values references a template which is rendered with the instance parameters and then used as chart values
+	// +optional
+	Values string `json:"values,omitempty"`
+}
+
 // PipeTask specifies a task that generates files and stores them for later usage in subsequent tasks
 type PipeTaskSpec struct {
 	Pod  string     `json:"pod"`
@@ -152,8 +187,34 @@ type PipeSpec struct {
 
 // OperatorVersionStatus defines the observed state of OperatorVersion.
 type OperatorVersionStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Conditions contains the Valid condition set by the verification of the operator version
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Errors are the problems found by the verification, plans of invalid operator versions are not executed
+	// +optional
+	Errors []string `json:"errors,omitempty"`
+	// Warnings are the potential problems found by the verification
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Condition types and reasons of an OperatorVersion
+const (
+	// OperatorVersionConditionValid is true if the verification of the operator version found no errors
+	OperatorVersionConditionValid = "Valid"
+
+	OperatorVersionReasonVerified           = "Verified"
+	OperatorVersionReasonVerificationFailed = "VerificationFailed"
+)
+
+// ValidCondition returns the Valid condition of the operator version or nil if the current generation of the
+// operator version wasn't verified yet
+func (ov *OperatorVersion) ValidCondition() *Condition {
+	c := FindCondition(ov.Status.Conditions, OperatorVersionConditionValid)
+	if c == nil || c.ObservedGeneration != ov.Generation {
+		return nil
+	}
+	return c
 }
 
 // +genclient
@@ -161,6 +222,7 @@ type OperatorVersionStatus struct {
 
 // OperatorVersion is the Schema for the operatorversions API.
 // +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
 type OperatorVersion struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
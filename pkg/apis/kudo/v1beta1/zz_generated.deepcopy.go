@@ -19,7 +19,8 @@ limitations under the License.
 package v1beta1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -55,6 +56,72 @@ func (in *Command) DeepCopy() *Command {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeleteSelector) DeepCopyInto(out *DeleteSelector) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]v1.TypeMeta, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteSelector.
+func (in *DeleteSelector) DeepCopy() *DeleteSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeleteTaskSpec) DeepCopyInto(out *DeleteTaskSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(DeleteSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteTaskSpec.
+func (in *DeleteTaskSpec) DeepCopy() *DeleteTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DummyTaskSpec) DeepCopyInto(out *DummyTaskSpec) {
 	*out = *in
@@ -71,6 +138,54 @@ func (in *DummyTaskSpec) DeepCopy() *DummyTaskSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmTaskSpec) DeepCopyInto(out *HelmTaskSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmTaskSpec.
+func (in *HelmTaskSpec) DeepCopy() *HelmTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverride) DeepCopyInto(out *ImageOverride) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverride.
+func (in *ImageOverride) DeepCopy() *ImageOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstalledOperatorVersion) DeepCopyInto(out *InstalledOperatorVersion) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstalledOperatorVersion.
+func (in *InstalledOperatorVersion) DeepCopy() *InstalledOperatorVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(InstalledOperatorVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -99,6 +214,51 @@ func (in *Instance) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceCustomization) DeepCopyInto(out *InstanceCustomization) {
+	*out = *in
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PatchesStrategicMerge != nil {
+		in, out := &in.PatchesStrategicMerge, &out.PatchesStrategicMerge
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PatchesJSON6902 != nil {
+		in, out := &in.PatchesJSON6902, &out.PatchesJSON6902
+		*out = make([]JSON6902Patch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageOverride, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceCustomization.
+func (in *InstanceCustomization) DeepCopy() *InstanceCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceList) DeepCopyInto(out *InstanceList) {
 	*out = *in
@@ -143,6 +303,11 @@ func (in *InstanceSpec) DeepCopyInto(out *InstanceSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Customization != nil {
+		in, out := &in.Customization, &out.Customization
+		*out = new(InstanceCustomization)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -167,6 +332,13 @@ func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
 		}
 	}
 	out.AggregatedStatus = in.AggregatedStatus
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -180,6 +352,23 @@ func (in *InstanceStatus) DeepCopy() *InstanceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSON6902Patch) DeepCopyInto(out *JSON6902Patch) {
+	*out = *in
+	out.Target = in.Target
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSON6902Patch.
+func (in *JSON6902Patch) DeepCopy() *JSON6902Patch {
+	if in == nil {
+		return nil
+	}
+	out := new(JSON6902Patch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Maintainer) DeepCopyInto(out *Maintainer) {
 	*out = *in
@@ -226,7 +415,7 @@ func (in *Operator) DeepCopyInto(out *Operator) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -328,6 +517,11 @@ func (in *OperatorSpec) DeepCopy() *OperatorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorStatus) DeepCopyInto(out *OperatorStatus) {
 	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]InstalledOperatorVersion, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -347,7 +541,7 @@ func (in *OperatorVersion) DeepCopyInto(out *OperatorVersion) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -441,7 +635,7 @@ func (in *OperatorVersionSpec) DeepCopyInto(out *OperatorVersionSpec) {
 	}
 	if in.UpgradableFrom != nil {
 		in, out := &in.UpgradableFrom, &out.UpgradableFrom
-		*out = make([]v1.ObjectReference, len(*in))
+		*out = make([]corev1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	return
@@ -460,6 +654,23 @@ func (in *OperatorVersionSpec) DeepCopy() *OperatorVersionSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorVersionStatus) DeepCopyInto(out *OperatorVersionStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -499,6 +710,22 @@ func (in *Parameter) DeepCopy() *Parameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchTarget) DeepCopyInto(out *PatchTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchTarget.
+func (in *PatchTarget) DeepCopy() *PatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Phase) DeepCopyInto(out *Phase) {
 	*out = *in
@@ -706,8 +933,10 @@ func (in *Task) DeepCopy() *Task {
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
 	in.ResourceTaskSpec.DeepCopyInto(&out.ResourceTaskSpec)
+	in.DeleteTaskSpec.DeepCopyInto(&out.DeleteTaskSpec)
 	out.DummyTaskSpec = in.DummyTaskSpec
 	in.PipeTaskSpec.DeepCopyInto(&out.PipeTaskSpec)
+	out.HelmTaskSpec = in.HelmTaskSpec
 	return
 }
 
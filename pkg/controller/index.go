@@ -0,0 +1,25 @@
+// Package controller contains the cache field indexes shared by the controllers of the KUDO manager. The controllers
+// themselves are in the sub packages.
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+)
+
+// AddIndexes registers the cache field indexes the KUDO controllers list objects by. It has to be called once with the
+// field indexer of the manager, before the controllers are set up.
+func AddIndexes(indexer client.FieldIndexer) error {
+	return indexer.IndexField(&kudov1beta1.Instance{}, kudov1beta1.InstanceOperatorVersionIndex, operatorVersionIndexValue)
+}
+
+// operatorVersionIndexValue indexes instances by the namespace and name of their operator version
+func operatorVersionIndexValue(obj runtime.Object) []string {
+	instance, ok := obj.(*kudov1beta1.Instance)
+	if !ok {
+		return nil
+	}
+	return []string{instance.OperatorVersionNamespacedName().String()}
+}
@@ -0,0 +1,94 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
+)
+
+// deleteUnownedResources deletes resources created by the instance that can not be owned by it: cluster-scoped
+// resources and resources in other namespaces. Kubernetes does not garbage collect them when the instance is deleted,
+// so they are tracked using labels instead (see kudo.InstanceNamespaceLabel). Only the kinds recorded on the instance
+// (see Instance.TrackUnownedResources) are searched for labeled resources. If the manager is limited to some
+// namespaces, only these namespaces are searched and cluster-scoped kinds are skipped, as the manager can neither
+// list nor create them. Returns true once no such resources are left.
+func (r *Reconciler) deleteUnownedResources(instance *kudov1beta1.Instance, log logr.Logger) (bool, error) {
+	selector := client.MatchingLabels{
+		kudo.HeritageLabel:          "kudo",
+		kudo.InstanceLabel:          instance.Name,
+		kudo.InstanceNamespaceLabel: instance.Namespace,
+	}
+
+	remaining := 0
+	for _, gvk := range instance.UnownedResourceKinds() {
+		namespaced, served, err := r.isNamespaced(gvk)
+		if err != nil {
+			return false, fmt.Errorf("failed to discover resource of kind %s: %w", gvk.String(), err)
+		}
+		if !served {
+			log.V(1).Info("kind of unowned resources is not served anymore", "kind", gvk.String())
+			continue
+		}
+
+		namespaces := []string{metav1.NamespaceAll}
+		if len(r.WatchNamespaces) > 0 {
+			if !namespaced {
+				log.Info("skipping cluster-scoped kind of unowned resources outside of the watched namespaces", "kind", gvk.String())
+				continue
+			}
+			namespaces = r.WatchNamespaces
+		}
+
+		for _, ns := range namespaces {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			if err := r.List(context.TODO(), list, selector, client.InNamespace(ns)); err != nil {
+				return false, fmt.Errorf("failed to list %s resources of instance %s/%s: %w", gvk.Kind, instance.Namespace, instance.Name, err)
+			}
+
+			for i := range list.Items {
+				item := &list.Items[i]
+				remaining++
+				if item.GetDeletionTimestamp() != nil {
+					continue // already being deleted, e.g. waiting for finalizers
+				}
+
+				log.Info("deleting unowned resource", "kind", item.GetKind(), "resourceNamespace", item.GetNamespace(), "resource", item.GetName())
+				err := r.Delete(context.TODO(), item, client.PropagationPolicy(metav1.DeletePropagationBackground))
+				if err != nil && !apierrors.IsNotFound(err) {
+					return false, fmt.Errorf("failed to delete %s %s/%s of instance %s/%s: %w", item.GetKind(), item.GetNamespace(), item.GetName(), instance.Namespace, instance.Name, err)
+				}
+			}
+		}
+	}
+
+	return remaining == 0, nil
+}
+
+// isNamespaced returns whether a kind is namespace-scoped, using the discovery API. served is false if the server
+// doesn't serve the kind (anymore), e.g. because its custom resource definition has been deleted.
+func (r *Reconciler) isNamespaced(gvk schema.GroupVersionKind) (namespaced bool, served bool, err error) {
+	resources, err := r.Discovery.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == gvk.Kind && !strings.Contains(resource.Name, "/") { // skip subresources
+			return resource.Namespaced, true, nil
+		}
+	}
+	return false, false, nil
+}
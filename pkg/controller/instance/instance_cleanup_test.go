@@ -0,0 +1,124 @@
+package instance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
+)
+
+func Test_deleteUnownedResources(t *testing.T) {
+	labels := func(instanceNamespace string) map[string]string {
+		return map[string]string{
+			kudo.HeritageLabel:          "kudo",
+			kudo.InstanceLabel:          "test",
+			kudo.InstanceNamespaceLabel: instanceNamespace,
+		}
+	}
+	discovery := &fake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps/status", Kind: "ConfigMap", Namespaced: false},
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"list", "delete"}},
+			},
+		},
+		{
+			GroupVersion: "scheduling.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "priorityclasses", Kind: "PriorityClass", Namespaced: false, Verbs: []string{"list", "delete"}},
+			},
+		},
+	}}}
+
+	tests := []struct {
+		name            string
+		kinds           string
+		watchNamespaces []string
+		deleted         []client.ObjectKey
+	}{
+		{
+			name:    "deletes resources of recorded kinds",
+			kinds:   "scheduling.k8s.io/v1/PriorityClass,v1/ConfigMap",
+			deleted: []client.ObjectKey{{Namespace: "other", Name: "tracked"}, {Namespace: "third", Name: "tracked"}, {Name: "class"}},
+		},
+		{
+			name:            "skips cluster-scoped kinds and other namespaces if namespaces are watched",
+			kinds:           "scheduling.k8s.io/v1/PriorityClass,v1/ConfigMap",
+			watchNamespaces: []string{"default", "other"},
+			deleted:         []client.ObjectKey{{Namespace: "other", Name: "tracked"}},
+		},
+		{
+			name:    "ignores kinds which are not served",
+			kinds:   "v1/ConfigMap,v1/Unknown",
+			deleted: []client.ObjectKey{{Namespace: "other", Name: "tracked"}, {Namespace: "third", Name: "tracked"}},
+		},
+		{
+			name: "does nothing without recorded kinds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &kudov1beta1.Instance{ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   "default",
+				Annotations: map[string]string{kudo.UnownedResourcesAnnotation: tt.kinds},
+			}}
+			objects := map[client.ObjectKey]func() runtime.Object{
+				{Namespace: "other", Name: "tracked"}: func() runtime.Object { return &corev1.ConfigMap{} },
+				{Namespace: "other", Name: "foreign"}: func() runtime.Object { return &corev1.ConfigMap{} },
+				{Namespace: "third", Name: "tracked"}: func() runtime.Object { return &corev1.ConfigMap{} },
+				{Name: "class"}:                       func() runtime.Object { return &schedulingv1.PriorityClass{} },
+			}
+
+			c := fakeclient.NewFakeClientWithScheme(scheme.Scheme,
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "tracked", Namespace: "other", Labels: labels("default")}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "other", Labels: labels("another")}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "tracked", Namespace: "third", Labels: labels("default")}},
+				&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "class", Labels: labels("default")}},
+			)
+			r := &Reconciler{Client: c, Discovery: discovery, WatchNamespaces: tt.watchNamespaces}
+
+			done, err := r.deleteUnownedResources(instance, log.NullLogger{})
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.deleted) == 0, done, "done once no resources were found")
+
+			for key, obj := range objects {
+				err = c.Get(context.TODO(), key, obj())
+				if contains(tt.deleted, key) {
+					assert.True(t, apierrors.IsNotFound(err), "%s is deleted", key)
+				} else {
+					assert.NoError(t, err, "%s is kept", key)
+				}
+			}
+
+			done, err = r.deleteUnownedResources(instance, log.NullLogger{})
+			assert.NoError(t, err)
+			assert.True(t, done)
+		})
+	}
+}
+
+func contains(keys []client.ObjectKey, key client.ObjectKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package instance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+)
+
+func TestReconcile_PendingPlanConditions(t *testing.T) {
+	tests := []struct {
+		name        string
+		valid       *kudov1beta1.Condition
+		errors      []string
+		reason      string
+		progressing metav1.ConditionStatus
+		degraded    metav1.ConditionStatus
+	}{
+		{
+			name:        "operator version not verified",
+			reason:      kudov1beta1.InstanceReasonOperatorVersionNotVerified,
+			progressing: metav1.ConditionTrue,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name:        "operator version verified for an older generation",
+			valid:       &kudov1beta1.Condition{Type: kudov1beta1.OperatorVersionConditionValid, Status: metav1.ConditionTrue, ObservedGeneration: 1},
+			reason:      kudov1beta1.InstanceReasonOperatorVersionNotVerified,
+			progressing: metav1.ConditionTrue,
+			degraded:    metav1.ConditionFalse,
+		},
+		{
+			name:        "invalid operator version",
+			valid:       &kudov1beta1.Condition{Type: kudov1beta1.OperatorVersionConditionValid, Status: metav1.ConditionFalse, ObservedGeneration: 2},
+			errors:      []string{"template missing"},
+			reason:      kudov1beta1.InstanceReasonInvalidOperatorVersion,
+			progressing: metav1.ConditionFalse,
+			degraded:    metav1.ConditionTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ov := &kudov1beta1.OperatorVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-1.0", Namespace: "default", Generation: 2},
+				Spec: kudov1beta1.OperatorVersionSpec{
+					Parameters: []kudov1beta1.Parameter{{Name: "replicas"}},
+					Plans:      map[string]kudov1beta1.Plan{kudov1beta1.DeployPlanName: {}},
+				},
+				Status: kudov1beta1.OperatorVersionStatus{Errors: tt.errors},
+			}
+			if tt.valid != nil {
+				ov.Status.Conditions = []kudov1beta1.Condition{*tt.valid}
+			}
+
+			// the deploy plan of generation 1 completed, generation 2 changed a parameter
+			instance := &kudov1beta1.Instance{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1},
+				Spec: kudov1beta1.InstanceSpec{
+					OperatorVersion: corev1.ObjectReference{Name: "test-1.0"},
+					Parameters:      map[string]string{"replicas": "1"},
+				},
+				Status: kudov1beta1.InstanceStatus{
+					PlanStatus: map[string]kudov1beta1.PlanStatus{
+						kudov1beta1.DeployPlanName: {Name: kudov1beta1.DeployPlanName, Status: kudov1beta1.ExecutionComplete, UID: "1"},
+					},
+				},
+			}
+			assert.NoError(t, instance.SaveSnapshot())
+			instance.UpdateConditions()
+			instance.Generation = 2
+			instance.Spec.Parameters = map[string]string{"replicas": "3"}
+
+			s := runtime.NewScheme()
+			assert.NoError(t, kudov1beta1.AddToScheme(s))
+			c := fakeclient.NewFakeClientWithScheme(s, instance, ov)
+			r := &Reconciler{Client: c, Recorder: record.NewFakeRecorder(10), Scheme: s, Log: log.NullLogger{}}
+
+			key := types.NamespacedName{Namespace: "default", Name: "test"}
+			_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+
+			updated := &kudov1beta1.Instance{}
+			assert.NoError(t, c.Get(context.TODO(), key, updated))
+			assert.Nil(t, updated.GetPlanInProgress(), "no plan is started")
+
+			expected := map[string]metav1.ConditionStatus{
+				kudov1beta1.InstanceConditionReady:       metav1.ConditionFalse,
+				kudov1beta1.InstanceConditionProgressing: tt.progressing,
+				kudov1beta1.InstanceConditionDegraded:    tt.degraded,
+			}
+			for conditionType, status := range expected {
+				condition := kudov1beta1.FindCondition(updated.Status.Conditions, conditionType)
+				if assert.NotNil(t, condition, conditionType) {
+					assert.Equal(t, status, condition.Status, conditionType)
+					assert.Equal(t, tt.reason, condition.Reason, conditionType)
+					assert.Equal(t, int64(1), condition.ObservedGeneration, "%s is not observed for the unapplied generation", conditionType)
+				}
+			}
+		})
+	}
+}
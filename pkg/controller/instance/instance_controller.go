@@ -19,69 +19,94 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/engine"
 	"github.com/kudobuilder/kudo/pkg/engine/renderer"
 	"github.com/kudobuilder/kudo/pkg/engine/task"
 	"github.com/kudobuilder/kudo/pkg/engine/workflow"
+	"github.com/kudobuilder/kudo/pkg/metrics"
 	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
+// resourcesDeletionRequeue is the interval in which a deleted instance is requeued while waiting for its unowned
+// resources to be gone
+const resourcesDeletionRequeue = 5 * time.Second
+
 // Reconciler reconciles an Instance object.
 type Reconciler struct {
 	client.Client
-	Recorder record.EventRecorder
-	Scheme   *runtime.Scheme
+	Discovery discovery.DiscoveryInterface
+	Recorder  record.EventRecorder
+	Scheme    *runtime.Scheme
+	// Log is the logger of the controller, every reconciliation adds the namespace and name of the instance
+	Log logr.Logger
+	// RegistryMirrors maps container image registries to their mirrors, used when rendering templates
+	RegistryMirrors map[string]string
+	// WatchNamespaces are the namespaces the manager is limited to, all namespaces are watched if empty
+	WatchNamespaces []string
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles, defaults to 1
+	MaxConcurrentReconciles int
+
+	dynamicWatches *dynamicWatches
+	mapper         meta.RESTMapper
 }
 
-// SetupWithManager registers this reconciler with the controller manager
+// SetupWithManager registers this reconciler with the controller manager. The instances of an operator version are
+// listed with the field index registered by controller.AddIndexes, which has to be called on the manager before.
 func (r *Reconciler) SetupWithManager(
 	mgr ctrl.Manager) error {
+	if r.Log == nil {
+		r.Log = ctrl.Log.WithName("controllers").WithName("instance")
+	}
+	r.mapper = mgr.GetRESTMapper()
+
 	addOvRelatedInstancesToReconcile := handler.ToRequestsFunc(
 		func(obj handler.MapObject) []reconcile.Request {
 			requests := make([]reconcile.Request, 0)
 			instances := &kudov1beta1.InstanceList{}
-			// we are listing all instances here, which could come with some performance penalty
-			// obj possible optimization is to introduce filtering based on operatorversion (or operator)
+			// we pick only those instances, that belong to the OperatorVersion we're reconciling
+			ov := types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: obj.Meta.GetName()}
 			err := mgr.GetClient().List(
 				context.TODO(),
 				instances,
+				client.MatchingFields{kudov1beta1.InstanceOperatorVersionIndex: ov.String()},
 			)
 			if err != nil {
-				log.Printf("InstanceController: Error fetching instances list for operator %v: %v", obj.Meta.GetName(), err)
+				r.Log.Error(err, "failed to list instances of operator version", "operatorVersion", ov.String())
 				return nil
 			}
 			for _, instance := range instances.Items {
-				// we need to pick only those instances, that belong to the OperatorVersion we're reconciling
-				if instance.Spec.OperatorVersion.Name == obj.Meta.GetName() &&
-					instance.OperatorVersionNamespace() == obj.Meta.GetNamespace() {
-					requests = append(requests, reconcile.Request{
-						NamespacedName: types.NamespacedName{
-							Name:      instance.Name,
-							Namespace: instance.Namespace,
-						},
-					})
-				}
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      instance.Name,
+						Namespace: instance.Namespace,
+					},
+				})
 			}
 			return requests
 		})
@@ -111,7 +136,7 @@ func (r *Reconciler) SetupWithManager(
 		GenericFunc: func(event.GenericEvent) bool { return true },
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&kudov1beta1.Instance{}).
 		Owns(&kudov1beta1.Instance{}).
 		Owns(&appsv1.Deployment{}).
@@ -120,8 +145,23 @@ func (r *Reconciler) SetupWithManager(
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Pod{}).
 		WithEventFilter(resPredicate).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Watches(&source.Kind{Type: &kudov1beta1.OperatorVersion{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: addOvRelatedInstancesToReconcile}).
-		Complete(r)
+		Build(r)
+	if err != nil {
+		return err
+	}
+
+	// other kinds created by operators are watched once an instance of their operator version is reconciled
+	r.dynamicWatches = newDynamicWatches(c, resPredicate, []schema.GroupKind{
+		kudov1beta1.SchemeGroupVersion.WithKind("Instance").GroupKind(),
+		appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind(),
+		corev1.SchemeGroupVersion.WithKind("Service").GroupKind(),
+		batchv1.SchemeGroupVersion.WithKind("Job").GroupKind(),
+		appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind(),
+		corev1.SchemeGroupVersion.WithKind("Pod").GroupKind(),
+	}, r.mapper, len(r.WatchNamespaces) > 0)
+	return nil
 }
 
 // Reconcile is the main controller method that gets called every time something about the instance changes
@@ -133,8 +173,8 @@ func (r *Reconciler) SetupWithManager(
 //                  |
 //                  v
 //   +-------------------------------+
-//   | Update finalizers if cleanup  |
-//   | plan exists                   |
+//   | Update finalizers and delete  |
+//   | unowned resources if deleted  |
 //   +-------------------------------+
 //                  |
 //                  v
@@ -159,54 +199,90 @@ func (r *Reconciler) SetupWithManager(
 func (r *Reconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 	// ---------- 1. Query the current state ----------
 
-	log.Printf("InstanceController: Received Reconcile request for instance \"%+v\"", request.Name)
+	log := r.Log.WithValues("namespace", request.Namespace, "instance", request.Name)
+	log.V(1).Info("received reconcile request")
 	instance, err := r.getInstance(request)
 	if err != nil {
 		if apierrors.IsNotFound(err) { // not retrying if instance not found, probably someone manually removed it?
-			log.Printf("Instance %s was deleted, nothing to reconcile.", request.NamespacedName)
+			log.Info("instance was deleted, nothing to reconcile")
+			metrics.ForgetInstance(request.NamespacedName.String())
 			return reconcile.Result{}, nil
 		}
+		log.Error(err, "failed to get instance")
 		return reconcile.Result{}, err
 	}
 	oldInstance := instance.DeepCopy()
 
-	ov, err := r.getOperatorVersion(instance)
+	ov, err := r.getOperatorVersion(instance, log)
 	if err != nil {
 		return reconcile.Result{}, err // OV not found has to be retried because it can really have been created after Instance
 	}
+	if r.dynamicWatches != nil {
+		r.dynamicWatches.ensure(ov, log)
+	}
 
 	// ---------- 2. Check if the object is being deleted ----------
 
 	if !instance.IsDeleting() {
 		if _, hasCleanupPlan := ov.Spec.Plans[kudov1beta1.CleanupPlanName]; hasCleanupPlan {
 			if instance.TryAddFinalizer() {
-				log.Printf("InstanceController: Adding finalizer on instance %s/%s", instance.Namespace, instance.Name)
+				log.Info("adding cleanup finalizer")
 			}
 		}
+		// the resources finalizer is added during plan execution, once the instance creates a resource it can not own
 	} else {
-		log.Printf("InstanceController: Instance %s/%s is being deleted", instance.Namespace, instance.Name)
+		log.Info("instance is being deleted")
+
+		// once the cleanup plan is done (or there is none) we delete resources the instance can not own
+		if !instance.HasCleanupFinalizer() && instance.HasResourcesFinalizer() {
+			return r.handleResourcesFinalizer(instance, log)
+		}
 	}
 
 	// ---------- 3. Check if we should start execution of new plan ----------
 
-	planToBeExecuted, err := instance.GetPlanToBeExecuted(ov)
+	planToBeExecuted, err := instance.GetPlanToBeExecuted(ov, log)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	planPending := false
+	if planToBeExecuted != nil && !instance.IsDeleting() {
+		if verified, problems := operatorVersionErrors(ov); !verified {
+			// the instance is reconciled again once the operator version controller sets the Valid condition
+			log.Info("not starting plan before the operator version is verified", "plan", kudo.StringValue(planToBeExecuted), "operatorVersion", ov.Name)
+			message := fmt.Sprintf("plan %s waits for operator version %s to be verified", kudo.StringValue(planToBeExecuted), ov.Name)
+			instance.SetPendingPlanConditions(kudov1beta1.InstanceReasonOperatorVersionNotVerified, message, false)
+			planToBeExecuted, planPending = nil, true
+		} else if len(problems) > 0 {
+			log.Info("not starting plan of invalid operator version", "plan", kudo.StringValue(planToBeExecuted), "operatorVersion", ov.Name, "errors", problems)
+			r.Recorder.Event(instance, "Warning", "InvalidOperatorVersion", fmt.Sprintf("Execution of plan %s refused, operator version %s is invalid: %s", kudo.StringValue(planToBeExecuted), ov.Name, strings.Join(problems, "; ")))
+			message := fmt.Sprintf("plan %s was refused, operator version %s is invalid: %s", kudo.StringValue(planToBeExecuted), ov.Name, strings.Join(problems, "; "))
+			instance.SetPendingPlanConditions(kudov1beta1.InstanceReasonInvalidOperatorVersion, message, true)
+			planToBeExecuted, planPending = nil, true
+		}
+	}
 	if planToBeExecuted != nil {
-		log.Printf("InstanceController: Going to start execution of plan %s on instance %s/%s", kudo.StringValue(planToBeExecuted), instance.Namespace, instance.Name)
+		log.Info("starting plan execution", "plan", kudo.StringValue(planToBeExecuted))
 		err = instance.StartPlanExecution(kudo.StringValue(planToBeExecuted), ov)
 		if err != nil {
-			return reconcile.Result{}, r.handleError(err, instance, oldInstance)
+			return reconcile.Result{}, r.handleError(err, instance, oldInstance, log)
 		}
 		r.Recorder.Event(instance, "Normal", "PlanStarted", fmt.Sprintf("Execution of plan %s started", kudo.StringValue(planToBeExecuted)))
+		metrics.PlanExecutionsStarted.WithLabelValues(ov.Spec.Operator.Name, kudo.StringValue(planToBeExecuted)).Inc()
 	}
 
 	// ---------- 4. If there's currently active plan, continue with the execution ----------
 
 	activePlanStatus := instance.GetPlanInProgress()
 	if activePlanStatus == nil { // we have no plan in progress
-		log.Printf("InstanceController: Nothing to do, no plan in progress for instance %s/%s", instance.Namespace, instance.Name)
+		log.V(1).Info("nothing to do, no plan in progress")
+		if !planPending {
+			instance.UpdateConditions()
+		}
+		if !reflect.DeepEqual(instance.ObjectMeta.Finalizers, oldInstance.ObjectMeta.Finalizers) ||
+			!reflect.DeepEqual(instance.Status, oldInstance.Status) {
+			return reconcile.Result{}, updateInstance(instance, oldInstance, r.Client, log)
+		}
 		return reconcile.Result{}, nil
 	}
 
@@ -218,28 +294,30 @@ func (r *Reconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 		OperatorName:        ov.Spec.Operator.Name,
 		InstanceNamespace:   instance.Namespace,
 		InstanceName:        instance.Name,
+		Customization:       instance.Spec.Customization,
 	}
 
+	log = log.WithValues("plan", activePlanStatus.Name, "planUID", activePlanStatus.UID)
 	activePlan, err := preparePlanExecution(instance, ov, activePlanStatus, metadata)
 	if err != nil {
-		err = r.handleError(err, instance, oldInstance)
+		err = r.handleError(err, instance, oldInstance, log)
 		return reconcile.Result{}, err
 	}
-	log.Printf("InstanceController: Going to proceed in execution of active plan %s on instance %s/%s", activePlan.Name, instance.Namespace, instance.Name)
-	newStatus, err := workflow.Execute(activePlan, metadata, r.Client, &renderer.KustomizeEnhancer{Scheme: r.Scheme}, time.Now())
+	log.V(1).Info("proceeding with the execution of the active plan")
+	newStatus, err := workflow.Execute(activePlan, metadata, r.Client, r.mapper, &renderer.KustomizeEnhancer{Scheme: r.Scheme, RegistryMirrors: r.RegistryMirrors, Log: log}, time.Now(), log)
 
 	// ---------- 5. Update status of instance after the execution proceeded ----------
 	if newStatus != nil {
 		instance.UpdateInstanceStatus(newStatus)
 	}
 	if err != nil {
-		err = r.handleError(err, instance, oldInstance)
+		err = r.handleError(err, instance, oldInstance, log)
 		return reconcile.Result{}, err
 	}
 
-	err = updateInstance(instance, oldInstance, r.Client)
+	instance.UpdateConditions()
+	err = updateInstance(instance, oldInstance, r.Client, log)
 	if err != nil {
-		log.Printf("InstanceController: Error when updating instance. %v", err)
 		return reconcile.Result{}, err
 	}
 
@@ -250,7 +328,47 @@ func (r *Reconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 	return reconcile.Result{}, nil
 }
 
-func updateInstance(instance *kudov1beta1.Instance, oldInstance *kudov1beta1.Instance, client client.Client) error {
+// operatorVersionErrors returns the verification errors of an operator version from the Valid condition set by the
+// operator version controller. An operator version is not verified yet if the condition is missing or was observed
+// for an older generation, plans of a just installed or updated package don't start before it is verified.
+func operatorVersionErrors(ov *kudov1beta1.OperatorVersion) (verified bool, errors []string) {
+	valid := ov.ValidCondition()
+	if valid == nil || valid.ObservedGeneration != ov.Generation {
+		return false, nil
+	}
+	if valid.Status == metav1.ConditionTrue {
+		return true, nil
+	}
+	if len(ov.Status.Errors) == 0 {
+		return true, []string{valid.Message}
+	}
+	return true, ov.Status.Errors
+}
+
+// handleResourcesFinalizer deletes all unowned resources of a deleted instance and removes the resources finalizer
+// once they are gone. Until then, the instance is periodically requeued.
+func (r *Reconciler) handleResourcesFinalizer(instance *kudov1beta1.Instance, log logr.Logger) (ctrl.Result, error) {
+	done, err := r.deleteUnownedResources(instance, log)
+	if err != nil {
+		log.Error(err, "failed to delete resources of the instance")
+		return reconcile.Result{}, err
+	}
+	if !done {
+		log.Info("waiting for resources of the instance to be deleted")
+		return reconcile.Result{RequeueAfter: resourcesDeletionRequeue}, nil
+	}
+
+	if instance.RemoveResourcesFinalizer() {
+		log.Info("removing resources finalizer")
+		if err := r.Update(context.TODO(), instance); err != nil {
+			log.Error(err, "failed to remove resources finalizer")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+func updateInstance(instance *kudov1beta1.Instance, oldInstance *kudov1beta1.Instance, client client.Client, log logr.Logger) error {
 	// update instance spec and metadata. this will not update Instance.Status field
 	if !reflect.DeepEqual(instance.Spec, oldInstance.Spec) ||
 		!reflect.DeepEqual(instance.ObjectMeta.Annotations, oldInstance.ObjectMeta.Annotations) ||
@@ -258,25 +376,26 @@ func updateInstance(instance *kudov1beta1.Instance, oldInstance *kudov1beta1.Ins
 		instanceStatus := instance.Status.DeepCopy()
 		err := client.Update(context.TODO(), instance)
 		if err != nil {
-			log.Printf("InstanceController: Error when updating instance spec. %v", err)
+			log.Error(err, "failed to update instance spec")
 			return err
 		}
 		instance.Status = *instanceStatus
 	}
 
-	// update instance status
+	// update instance status, the conditions are set by the caller
 	err := client.Status().Update(context.TODO(), instance)
 	if err != nil {
-		log.Printf("InstanceController: Error when updating instance status. %v", err)
+		log.Error(err, "failed to update instance status")
 		return err
 	}
+	finishPlanMetrics(instance, time.Now())
 
 	// update instance metadata if finalizer is removed
 	// because Kubernetes might immediately delete the instance, this has to be the last instance update
 	if instance.TryRemoveFinalizer() {
-		log.Printf("InstanceController: Removing finalizer on instance %s/%s", instance.Namespace, instance.Name)
+		log.Info("removing cleanup finalizer")
 		if err := client.Update(context.TODO(), instance); err != nil {
-			log.Printf("InstanceController: Error when removing instance finalizer. %v", err)
+			log.Error(err, "failed to remove cleanup finalizer")
 			return err
 		}
 	}
@@ -284,14 +403,27 @@ func updateInstance(instance *kudov1beta1.Instance, oldInstance *kudov1beta1.Ins
 	return nil
 }
 
+// finishPlanMetrics records the plan metrics of finished plans once their status is persisted. Each plan execution is
+// only finished once, plans that finished in earlier reconciles are ignored.
+func finishPlanMetrics(instance *kudov1beta1.Instance, t time.Time) {
+	for _, ps := range instance.Status.PlanStatus {
+		switch ps.Status {
+		case kudov1beta1.ExecutionComplete:
+			metrics.FinishPlan(fmt.Sprintf("%s/%s", instance.Namespace, instance.Name), ps.UID, metrics.PlanResultComplete, t)
+		case kudov1beta1.ExecutionFatalError:
+			metrics.FinishPlan(fmt.Sprintf("%s/%s", instance.Namespace, instance.Name), ps.UID, metrics.PlanResultFatalError, t)
+		}
+	}
+}
+
 func preparePlanExecution(instance *kudov1beta1.Instance, ov *kudov1beta1.OperatorVersion, activePlanStatus *kudov1beta1.PlanStatus, meta *engine.Metadata) (*workflow.ActivePlan, error) {
 	planSpec, ok := ov.Spec.Plans[activePlanStatus.Name]
 	if !ok {
 		return nil, &engine.ExecutionError{Err: fmt.Errorf("%wcould not find required plan: %v", engine.ErrFatalExecution, activePlanStatus.Name), EventName: "InvalidPlan"}
 	}
 
-	params := paramsMap(instance, ov)
-	pipes, err := pipesMap(activePlanStatus.Name, &planSpec, ov.Spec.Tasks, meta)
+	params := workflow.Params(instance.Spec.Parameters, ov.Spec.Parameters)
+	pipes, err := workflow.Pipes(activePlanStatus.Name, &planSpec, ov.Spec.Tasks, meta)
 	if err != nil {
 		return nil, &engine.ExecutionError{Err: fmt.Errorf("%wcould not make task pipes: %v", engine.ErrFatalExecution, err), EventName: "InvalidPlan"}
 	}
@@ -310,13 +442,13 @@ func preparePlanExecution(instance *kudov1beta1.Instance, ov *kudov1beta1.Operat
 // handleError handles execution error by logging, updating the plan status and optionally publishing an event
 // specify eventReason as nil if you don't wish to publish a warning event
 // returns err if this err should be retried, nil otherwise
-func (r *Reconciler) handleError(err error, instance *kudov1beta1.Instance, oldInstance *kudov1beta1.Instance) error {
-	log.Printf("InstanceController: %v", err)
+func (r *Reconciler) handleError(err error, instance *kudov1beta1.Instance, oldInstance *kudov1beta1.Instance, log logr.Logger) error {
+	log.Error(err, "plan execution failed")
 
 	// first update instance as we want to propagate errors also to the `Instance.Status.PlanStatus`
-	clientErr := updateInstance(instance, oldInstance, r.Client)
+	instance.UpdateConditions()
+	clientErr := updateInstance(instance, oldInstance, r.Client, log)
 	if clientErr != nil {
-		log.Printf("InstanceController: Error when updating instance state. %v", clientErr)
 		return clientErr
 	}
 
@@ -346,16 +478,20 @@ func (r *Reconciler) getInstance(request ctrl.Request) (instance *kudov1beta1.In
 	err = r.Get(context.TODO(), request.NamespacedName, instance)
 	if err != nil {
 		// Error reading the object - requeue the request.
-		log.Printf("InstanceController: Error getting instance \"%v\": %v",
-			request.NamespacedName,
-			err)
 		return nil, err
 	}
 	return instance, nil
 }
 
 // getOperatorVersion retrieves operatorversion belonging to the given instance
-func (r *Reconciler) getOperatorVersion(instance *kudov1beta1.Instance) (ov *kudov1beta1.OperatorVersion, err error) {
+func (r *Reconciler) getOperatorVersion(instance *kudov1beta1.Instance, log logr.Logger) (ov *kudov1beta1.OperatorVersion, err error) {
+	if !r.watches(instance.OperatorVersionNamespace()) {
+		err = fmt.Errorf("operatorVersion namespace %s is not watched by KUDO", instance.OperatorVersionNamespace())
+		log.Error(err, "failed to get operator version", "operatorVersion", instance.Spec.OperatorVersion.Name)
+		r.Recorder.Event(instance, "Warning", "InvalidOperatorVersion", fmt.Sprintf("Error getting operatorVersion \"%v\": %v", instance.Spec.OperatorVersion.Name, err))
+		return nil, err
+	}
+
 	ov = &kudov1beta1.OperatorVersion{}
 	err = r.Get(context.TODO(),
 		types.NamespacedName{
@@ -364,69 +500,22 @@ func (r *Reconciler) getOperatorVersion(instance *kudov1beta1.Instance) (ov *kud
 		},
 		ov)
 	if err != nil {
-		log.Printf("InstanceController: Error getting operatorVersion \"%v\" for instance \"%v\": %v",
-			instance.Spec.OperatorVersion.Name,
-			instance.Name,
-			err)
+		log.Error(err, "failed to get operator version", "operatorVersion", instance.Spec.OperatorVersion.Name)
 		r.Recorder.Event(instance, "Warning", "InvalidOperatorVersion", fmt.Sprintf("Error getting operatorVersion \"%v\": %v", instance.Spec.OperatorVersion.Name, err))
 		return nil, err
 	}
 	return ov, nil
 }
 
-// paramsMap generates {{ Params.* }} map of keys and values which is later used during template rendering.
-func paramsMap(instance *kudov1beta1.Instance, operatorVersion *kudov1beta1.OperatorVersion) map[string]string {
-	params := make(map[string]string)
-
-	for k, v := range instance.Spec.Parameters {
-		params[k] = v
-	}
-
-	// Merge instance parameter overrides with operator version, if no override exist, use the default one
-	for _, param := range operatorVersion.Spec.Parameters {
-		if _, ok := params[param.Name]; !ok {
-			params[param.Name] = kudo.StringValue(param.Default)
-		}
+// watches returns true if the manager watches the namespace
+func (r *Reconciler) watches(namespace string) bool {
+	if len(r.WatchNamespaces) == 0 {
+		return true
 	}
-
-	return params
-}
-
-// pipesMap generates {{ Pipes.* }} map of keys and values which is later used during template rendering.
-func pipesMap(planName string, plan *v1beta1.Plan, tasks []v1beta1.Task, emeta *engine.Metadata) (map[string]string, error) {
-	taskByName := func(name string) (*v1beta1.Task, bool) {
-		for _, t := range tasks {
-			if t.Name == name {
-				return &t, true
-			}
-		}
-		return nil, false
-	}
-
-	pipes := make(map[string]string)
-
-	for _, ph := range plan.Phases {
-		for _, st := range ph.Steps {
-			for _, tn := range st.Tasks {
-				rmeta := renderer.Metadata{
-					Metadata:  *emeta,
-					PlanName:  planName,
-					PhaseName: ph.Name,
-					StepName:  st.Name,
-					TaskName:  tn,
-				}
-
-				if t, ok := taskByName(tn); ok && t.Kind == task.PipeTaskKind {
-					for _, pipe := range t.Spec.PipeTaskSpec.Pipe {
-						if _, ok := pipes[pipe.Key]; ok {
-							return nil, fmt.Errorf("duplicated pipe key %s", pipe.Key)
-						}
-						pipes[pipe.Key] = task.PipeArtifactName(rmeta, pipe.Key)
-					}
-				}
-			}
+	for _, ns := range r.WatchNamespaces {
+		if ns == namespace {
+			return true
 		}
 	}
-
-	return pipes, nil
+	return false
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,7 +24,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
-	"github.com/kudobuilder/kudo/pkg/engine"
+	"github.com/kudobuilder/kudo/pkg/controller"
+	"github.com/kudobuilder/kudo/pkg/controller/operatorversion"
 	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
@@ -122,11 +124,18 @@ func instancePlanFinished(key client.ObjectKey, planName string, c client.Client
 func startTestManager(t *testing.T) (chan struct{}, *sync.WaitGroup, client.Client) {
 	mgr, err := manager.New(cfg, manager.Options{})
 	assert.Nil(t, err, "Error when creating manager")
+	err = controller.AddIndexes(mgr.GetFieldIndexer())
+	assert.Nil(t, err, "Error when adding field indexes")
 	err = (&Reconciler{
-		Client:   mgr.GetClient(),
-		Recorder: mgr.GetEventRecorderFor("instance-controller"),
-		Scheme:   mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Discovery: discovery.NewDiscoveryClientForConfigOrDie(mgr.GetConfig()),
+		Recorder:  mgr.GetEventRecorderFor("instance-controller"),
+		Scheme:    mgr.GetScheme(),
 	}).SetupWithManager(mgr)
+	assert.Nil(t, err, "Error when setting up instance controller")
+	// plans only start once the operator version is verified
+	err = (&operatorversion.Reconciler{Client: mgr.GetClient()}).SetupWithManager(mgr)
+	assert.Nil(t, err, "Error when setting up operator version controller")
 
 	stop := make(chan struct{})
 	wg := &sync.WaitGroup{}
@@ -137,192 +146,3 @@ func startTestManager(t *testing.T) (chan struct{}, *sync.WaitGroup, client.Clie
 	}()
 	return stop, wg, mgr.GetClient()
 }
-
-func Test_makePipes(t *testing.T) {
-	meta := &engine.Metadata{
-		InstanceName:        "first-operator-instance",
-		InstanceNamespace:   "default",
-		OperatorName:        "first-operator",
-		OperatorVersionName: "first-operator-1.0",
-		OperatorVersion:     "1.0",
-	}
-
-	tests := []struct {
-		name     string
-		planName string
-		plan     *v1beta1.Plan
-		tasks    []v1beta1.Task
-		emeta    *engine.Metadata
-		want     map[string]string
-		wantErr  bool
-	}{
-		{
-			name:     "no tasks, no pipes",
-			planName: "deploy",
-			plan: &v1beta1.Plan{Strategy: "serial", Phases: []v1beta1.Phase{
-				{
-					Name: "phase", Strategy: "serial", Steps: []v1beta1.Step{
-						{
-							Name: "step", Tasks: []string{}},
-					}},
-			}},
-			tasks: []v1beta1.Task{},
-			emeta: meta,
-			want:  map[string]string{},
-		},
-		{
-			name:     "no pipe tasks, no pipes",
-			planName: "deploy",
-			plan: &v1beta1.Plan{Strategy: "serial", Phases: []v1beta1.Phase{
-				{
-					Name: "phase", Strategy: "serial", Steps: []v1beta1.Step{
-						{
-							Name: "step", Tasks: []string{"task"}},
-					}},
-			}},
-			tasks: []v1beta1.Task{
-				{
-					Name: "task",
-					Kind: "Dummy",
-					Spec: v1beta1.TaskSpec{
-						DummyTaskSpec: v1beta1.DummyTaskSpec{Done: false},
-					},
-				},
-			},
-			emeta: meta,
-			want:  map[string]string{},
-		},
-		{
-			name:     "one pipe task, one pipes element",
-			planName: "deploy",
-			plan: &v1beta1.Plan{Strategy: "serial", Phases: []v1beta1.Phase{
-				{
-					Name: "phase", Strategy: "serial", Steps: []v1beta1.Step{
-						{
-							Name: "step", Tasks: []string{"task"}},
-					}},
-			}},
-			tasks: []v1beta1.Task{
-				{
-					Name: "task",
-					Kind: "Pipe",
-					Spec: v1beta1.TaskSpec{
-						PipeTaskSpec: v1beta1.PipeTaskSpec{
-							Pod: "pipe-pod.yaml",
-							Pipe: []v1beta1.PipeSpec{
-								{
-									File: "foo.txt",
-									Kind: "Secret",
-									Key:  "Foo",
-								},
-							},
-						},
-					},
-				},
-			},
-			emeta: meta,
-			want:  map[string]string{"Foo": "firstoperatorinstance.deploy.phase.step.task.foo"},
-		},
-		{
-			name:     "two pipe tasks, two pipes element",
-			planName: "deploy",
-			plan: &v1beta1.Plan{Strategy: "serial", Phases: []v1beta1.Phase{
-				{
-					Name: "phase", Strategy: "serial", Steps: []v1beta1.Step{
-						{Name: "stepOne", Tasks: []string{"task-one"}},
-						{Name: "stepTwo", Tasks: []string{"task-two"}},
-					}},
-			}},
-			tasks: []v1beta1.Task{
-				{
-					Name: "task-one",
-					Kind: "Pipe",
-					Spec: v1beta1.TaskSpec{
-						PipeTaskSpec: v1beta1.PipeTaskSpec{
-							Pod: "pipe-pod.yaml",
-							Pipe: []v1beta1.PipeSpec{
-								{
-									File: "foo.txt",
-									Kind: "Secret",
-									Key:  "Foo",
-								},
-							},
-						},
-					},
-				},
-				{
-					Name: "task-two",
-					Kind: "Pipe",
-					Spec: v1beta1.TaskSpec{
-						PipeTaskSpec: v1beta1.PipeTaskSpec{
-							Pod: "pipe-pod.yaml",
-							Pipe: []v1beta1.PipeSpec{
-								{
-									File: "bar.txt",
-									Kind: "ConfigMap",
-									Key:  "Bar",
-								},
-							},
-						},
-					},
-				},
-			},
-			emeta: meta,
-			want: map[string]string{
-				"Foo": "firstoperatorinstance.deploy.phase.stepone.taskone.foo",
-				"Bar": "firstoperatorinstance.deploy.phase.steptwo.tasktwo.bar",
-			},
-		},
-		{
-			name:     "one pipe task, duplicated pipe keys",
-			planName: "deploy",
-			plan: &v1beta1.Plan{Strategy: "serial", Phases: []v1beta1.Phase{
-				{
-					Name: "phase", Strategy: "serial", Steps: []v1beta1.Step{
-						{
-							Name: "step", Tasks: []string{"task"}},
-					}},
-			}},
-			tasks: []v1beta1.Task{
-				{
-					Name: "task",
-					Kind: "Pipe",
-					Spec: v1beta1.TaskSpec{
-						PipeTaskSpec: v1beta1.PipeTaskSpec{
-							Pod: "pipe-pod.yaml",
-							Pipe: []v1beta1.PipeSpec{
-								{
-									File: "foo.txt",
-									Kind: "Secret",
-									Key:  "Foo",
-								},
-								{
-									File: "bar.txt",
-									Kind: "ConfigMap",
-									Key:  "Foo",
-								},
-							},
-						},
-					},
-				},
-			},
-			emeta:   meta,
-			want:    nil,
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := pipesMap(tt.planName, tt.plan, tt.tasks, tt.emeta)
-			if err != nil {
-				if !tt.wantErr {
-					t.Fatalf("pipesMap() error = %v, wantErr %v", err, tt.wantErr)
-				}
-				return
-			}
-
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
@@ -0,0 +1,142 @@
+package instance
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+)
+
+var (
+	documentSeparator = regexp.MustCompile(`(?m)^---`)
+	apiVersionField   = regexp.MustCompile(`(?m)^apiVersion:[ \t]*(.*)$`)
+	kindField         = regexp.MustCompile(`(?m)^kind:[ \t]*(.*)$`)
+)
+
+// templateKinds returns the kinds of the resources defined in the templates of an operator version. Templates are not
+// rendered, the kinds are read from the top level apiVersion and kind fields of every YAML document. Templated values
+// can not be resolved without rendering and are skipped.
+func templateKinds(templates map[string]string) []schema.GroupVersionKind {
+	found := map[schema.GroupVersionKind]bool{}
+	for _, t := range templates {
+		for _, doc := range documentSeparator.Split(t, -1) {
+			apiVersion, kind := fieldValue(apiVersionField, doc), fieldValue(kindField, doc)
+			if apiVersion == "" || kind == "" {
+				continue
+			}
+			gv, err := schema.ParseGroupVersion(apiVersion)
+			if err != nil {
+				continue
+			}
+			found[gv.WithKind(kind)] = true
+		}
+	}
+
+	kinds := make([]schema.GroupVersionKind, 0, len(found))
+	for gvk := range found {
+		kinds = append(kinds, gvk)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].String() < kinds[j].String() })
+	return kinds
+}
+
+func fieldValue(field *regexp.Regexp, doc string) string {
+	m := field.FindStringSubmatch(doc)
+	if m == nil {
+		return ""
+	}
+	v := strings.Trim(strings.TrimSpace(m[1]), `"'`)
+	if strings.Contains(v, "{{") || strings.Contains(v, " ") {
+		return ""
+	}
+	return v
+}
+
+// dynamicWatches registers watches for the kinds created by operator versions which are not watched by the instance
+// controller from the start (see SetupWithManager). Like the static watches, they enqueue the owning instance of a
+// changed resource, so health of e.g. config maps or custom resources is rechecked right away instead of on resync.
+type dynamicWatches struct {
+	mu         sync.Mutex
+	controller controller.Controller
+	predicate  predicate.Predicate
+	// mapper resolves the scope of kinds, cluster-scoped kinds are not watched if the manager is limited to namespaces
+	mapper     meta.RESTMapper
+	namespaced bool
+	// watched kinds, the version is ignored as any version of a kind is served by the same resources
+	watched map[schema.GroupKind]bool
+	// generations of operator versions whose kinds are all watched
+	operatorVersions map[types.UID]int64
+}
+
+func newDynamicWatches(c controller.Controller, p predicate.Predicate, static []schema.GroupKind, mapper meta.RESTMapper, namespaced bool) *dynamicWatches {
+	w := &dynamicWatches{
+		controller:       c,
+		predicate:        p,
+		mapper:           mapper,
+		namespaced:       namespaced,
+		watched:          map[schema.GroupKind]bool{},
+		operatorVersions: map[types.UID]int64{},
+	}
+	for _, gk := range static {
+		w.watched[gk] = true
+	}
+	return w
+}
+
+// ensure registers watches for all kinds of the templates of an operator version. A kind which can not be watched
+// yet, e.g. a custom resource whose definition is created by a plan of the operator, is retried with the next call.
+// If the manager is limited to namespaces, cluster-scoped kinds are skipped: its role can't list or watch them.
+func (w *dynamicWatches) ensure(ov *kudov1beta1.OperatorVersion, log logr.Logger) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if generation, ok := w.operatorVersions[ov.UID]; ok && generation == ov.Generation {
+		return
+	}
+
+	complete := true
+	for _, gvk := range templateKinds(ov.Spec.Templates) {
+		if w.watched[gvk.GroupKind()] {
+			continue
+		}
+		if w.namespaced && w.mapper != nil {
+			mapping, err := w.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			if err != nil {
+				log.Error(err, "unable to resolve scope of kind of operator version, will retry", "kind", gvk.String(), "operatorVersion", ov.Name)
+				complete = false
+				continue
+			}
+			if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+				log.V(1).Info("not watching cluster-scoped kind of operator version", "kind", gvk.String(), "operatorVersion", ov.Name)
+				continue
+			}
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		err := w.controller.Watch(&source.Kind{Type: obj}, &handler.EnqueueRequestForOwner{OwnerType: &kudov1beta1.Instance{}, IsController: true}, w.predicate)
+		if err != nil {
+			log.Error(err, "unable to watch kind of operator version, will retry", "kind", gvk.String(), "operatorVersion", ov.Name)
+			complete = false
+			continue
+		}
+		log.Info("watching kind of operator version", "kind", gvk.String(), "operatorVersion", ov.Name)
+		w.watched[gvk.GroupKind()] = true
+	}
+
+	if complete {
+		w.operatorVersions[ov.UID] = ov.Generation
+	}
+}
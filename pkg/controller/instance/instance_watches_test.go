@@ -0,0 +1,124 @@
+package instance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+)
+
+func TestTemplateKinds(t *testing.T) {
+	templates := map[string]string{
+		"cm.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Name }}
+---
+apiVersion: "policy/v1beta1"
+kind: PodDisruptionBudget
+`,
+		"templated.yaml": `apiVersion: {{ .Params.API_VERSION }}
+kind: Deployment
+`,
+		"nested.yaml": `apiVersion: example.com/v1
+kind: Cluster
+spec:
+  template:
+    apiVersion: v1
+    kind: Secret
+`,
+	}
+
+	assert.Equal(t, []schema.GroupVersionKind{
+		{Version: "v1", Kind: "ConfigMap"},
+		{Group: "example.com", Version: "v1", Kind: "Cluster"},
+		{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"},
+	}, templateKinds(templates))
+}
+
+type fakeController struct {
+	watched []schema.GroupVersionKind
+	failing map[string]bool
+}
+
+func (c *fakeController) Reconcile(ctrl.Request) (ctrl.Result, error) { return ctrl.Result{}, nil }
+
+func (c *fakeController) Start(<-chan struct{}) error { return nil }
+
+func (c *fakeController) Watch(src source.Source, _ handler.EventHandler, _ ...predicate.Predicate) error {
+	gvk := src.(*source.Kind).Type.(*unstructured.Unstructured).GroupVersionKind()
+	if c.failing[gvk.Kind] {
+		return errors.New("no matches for kind")
+	}
+	c.watched = append(c.watched, gvk)
+	return nil
+}
+
+func TestDynamicWatches(t *testing.T) {
+	c := &fakeController{failing: map[string]bool{"Cluster": true}}
+	w := newDynamicWatches(c, predicate.Funcs{}, []schema.GroupKind{{Group: "apps", Kind: "Deployment"}}, nil, false)
+
+	ov := &v1beta1.OperatorVersion{}
+	ov.UID = "ov"
+	ov.Generation = 1
+	ov.Spec.Templates = map[string]string{
+		"deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\n",
+		"cm.yaml":         "apiVersion: v1\nkind: ConfigMap\n",
+		"cluster.yaml":    "apiVersion: example.com/v1\nkind: Cluster\n",
+	}
+
+	w.ensure(ov, log.NullLogger{})
+	assert.Equal(t, []schema.GroupVersionKind{{Version: "v1", Kind: "ConfigMap"}}, c.watched)
+
+	// the custom resource definition was created in the meantime
+	c.failing = nil
+	w.ensure(ov, log.NullLogger{})
+	assert.Equal(t, []schema.GroupVersionKind{
+		{Version: "v1", Kind: "ConfigMap"},
+		{Group: "example.com", Version: "v1", Kind: "Cluster"},
+	}, c.watched)
+
+	// all kinds of the operator version are watched now
+	c.failing = map[string]bool{"ConfigMap": true}
+	w.ensure(ov, log.NullLogger{})
+	assert.Equal(t, 2, len(c.watched))
+}
+
+func TestDynamicWatches_Namespaced(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}, meta.RESTScopeRoot)
+
+	c := &fakeController{}
+	w := newDynamicWatches(c, predicate.Funcs{}, nil, mapper, true)
+
+	ov := &v1beta1.OperatorVersion{}
+	ov.UID = "ov"
+	ov.Generation = 1
+	ov.Spec.Templates = map[string]string{
+		"cm.yaml":          "apiVersion: v1\nkind: ConfigMap\n",
+		"clusterrole.yaml": "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\n",
+		"cluster.yaml":     "apiVersion: example.com/v1\nkind: Cluster\n",
+	}
+
+	// the scope of the custom resource is unknown until its definition is created
+	w.ensure(ov, log.NullLogger{})
+	assert.Equal(t, []schema.GroupVersionKind{{Version: "v1", Kind: "ConfigMap"}}, c.watched)
+
+	mapper.Add(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Cluster"}, meta.RESTScopeNamespace)
+	w.ensure(ov, log.NullLogger{})
+	assert.Equal(t, []schema.GroupVersionKind{
+		{Version: "v1", Kind: "ConfigMap"},
+		{Group: "example.com", Version: "v1", Kind: "Cluster"},
+	}, c.watched)
+}
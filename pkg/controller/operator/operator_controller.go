@@ -17,12 +17,21 @@ package operator
 
 import (
 	"context"
-	"log"
+	"reflect"
+	"sort"
 
+	"github.com/Masterminds/semver"
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 )
@@ -30,19 +39,70 @@ import (
 // Reconciler reconciles an Operator object
 type Reconciler struct {
 	client.Client
+	Log logr.Logger
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles, defaults to 1
+	MaxConcurrentReconciles int
 }
 
-// SetupWithManager registers this reconciler with the controller manager
+// SetupWithManager registers this reconciler with the controller manager. The instances of an operator version are
+// listed with the field index registered by controller.AddIndexes, which has to be called on the manager before.
 func (r *Reconciler) SetupWithManager(
 	mgr ctrl.Manager) error {
+	if r.Log == nil {
+		r.Log = ctrl.Log.WithName("controllers").WithName("operator")
+	}
+
+	// the status of an operator changes with its operator versions and the instances using them
+	operatorOfOperatorVersion := handler.ToRequestsFunc(
+		func(obj handler.MapObject) []reconcile.Request {
+			ov, ok := obj.Object.(*kudov1beta1.OperatorVersion)
+			if !ok {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: ov.Namespace, Name: ov.Spec.Operator.Name}}}
+		})
+	operatorOfInstance := handler.ToRequestsFunc(
+		func(obj handler.MapObject) []reconcile.Request {
+			instance, ok := obj.Object.(*kudov1beta1.Instance)
+			if !ok {
+				return nil
+			}
+			ov := &kudov1beta1.OperatorVersion{}
+			if err := mgr.GetClient().Get(context.TODO(), instance.OperatorVersionNamespacedName(), ov); err != nil {
+				if !errors.IsNotFound(err) {
+					r.Log.Error(err, "failed to get operator version of instance", "instance", instance.Namespace+"/"+instance.Name)
+				}
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: ov.Namespace, Name: ov.Spec.Operator.Name}}}
+		})
+
+	// instances are updated on every step of a plan, only changes of their operator version affect the operator status
+	instancePredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldInstance, ok := e.ObjectOld.(*kudov1beta1.Instance)
+			if !ok {
+				return true
+			}
+			newInstance, ok := e.ObjectNew.(*kudov1beta1.Instance)
+			if !ok {
+				return true
+			}
+			return oldInstance.OperatorVersionNamespacedName() != newInstance.OperatorVersionNamespacedName()
+		},
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kudov1beta1.Operator{}).
+		Watches(&source.Kind{Type: &kudov1beta1.OperatorVersion{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: operatorOfOperatorVersion}).
+		Watches(&source.Kind{Type: &kudov1beta1.Instance{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: operatorOfInstance}).
+		WithEventFilter(instancePredicate).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
 
-// Reconcile reads that state of the cluster for an Operator object and makes changes based on the state read
-// and what is in the Operator.Spec
-// Automatically generate RBAC rules to allow the Controller to read and write Deployments
+// Reconcile updates the status of an Operator with its installed OperatorVersions, ordered by their semantic version,
+// and the number of instances using them.
 func (r *Reconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 	// Fetch the operator
 	operator := &kudov1beta1.Operator{}
@@ -57,7 +117,92 @@ func (r *Reconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	log.Printf("OperatorController: Received Reconcile request for an operator named: %v", request.Name)
+	log := r.Log.WithValues("namespace", request.Namespace, "operator", request.Name)
+	log.V(1).Info("received reconcile request")
+
+	ovs := &kudov1beta1.OperatorVersionList{}
+	if err := r.List(context.TODO(), ovs, client.InNamespace(operator.Namespace)); err != nil {
+		log.Error(err, "failed to list operator versions")
+		return reconcile.Result{}, err
+	}
+	// instances in other namespaces can use the operator versions too, they are listed with the operator version
+	// field index registered by controller.AddIndexes
+	usage := map[types.NamespacedName]int{}
+	for _, ov := range ovs.Items {
+		if ov.Spec.Operator.Name != operator.Name {
+			continue
+		}
+		key := types.NamespacedName{Namespace: ov.Namespace, Name: ov.Name}
+		instances := &kudov1beta1.InstanceList{}
+		if err := r.List(context.TODO(), instances, client.MatchingFields{kudov1beta1.InstanceOperatorVersionIndex: key.String()}); err != nil {
+			log.Error(err, "failed to list instances of operator version", "operatorVersion", ov.Name)
+			return reconcile.Result{}, err
+		}
+		usage[key] = len(instances.Items)
+	}
+
+	status := operatorStatus(operator, ovs.Items, usage)
+	if reflect.DeepEqual(status, operator.Status) {
+		return reconcile.Result{}, nil
+	}
 
+	operator.Status = status
+	if err := r.Status().Update(context.TODO(), operator); err != nil {
+		log.Error(err, "failed to update operator status")
+		return reconcile.Result{}, err
+	}
 	return reconcile.Result{}, nil
 }
+
+// operatorStatus returns the status of an operator based on the operator versions of its namespace and the number of
+// instances using each of them
+func operatorStatus(operator *kudov1beta1.Operator, ovs []kudov1beta1.OperatorVersion, usage map[types.NamespacedName]int) kudov1beta1.OperatorStatus {
+	status := kudov1beta1.OperatorStatus{}
+	for _, ov := range ovs {
+		if ov.Namespace != operator.Namespace || ov.Spec.Operator.Name != operator.Name {
+			continue
+		}
+		status.Versions = append(status.Versions, kudov1beta1.InstalledOperatorVersion{
+			Name:       ov.Name,
+			Version:    ov.Spec.Version,
+			AppVersion: ov.Spec.AppVersion,
+			Instances:  usage[types.NamespacedName{Namespace: ov.Namespace, Name: ov.Name}],
+		})
+	}
+	if len(status.Versions) == 0 {
+		return status
+	}
+
+	sort.SliceStable(status.Versions, func(i, j int) bool {
+		return versionLess(status.Versions[i], status.Versions[j])
+	})
+	status.LatestVersion = status.Versions[len(status.Versions)-1].Version
+
+	for _, v := range status.Versions {
+		status.Instances += v.Instances
+		if v.Version != status.LatestVersion {
+			status.OutdatedInstances += v.Instances
+		}
+	}
+	return status
+}
+
+// versionLess orders operator versions by their semantic version. Versions which aren't valid semantic versions are
+// ordered before all valid ones, equal versions by their name.
+func versionLess(a, b kudov1beta1.InstalledOperatorVersion) bool {
+	va, errA := semver.NewVersion(a.Version)
+	vb, errB := semver.NewVersion(b.Version)
+	switch {
+	case errA != nil && errB != nil:
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+	case errA != nil:
+		return true
+	case errB != nil:
+		return false
+	case !va.Equal(vb):
+		return va.LessThan(vb)
+	}
+	return a.Name < b.Name
+}
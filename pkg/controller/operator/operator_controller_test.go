@@ -0,0 +1,170 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/controller"
+)
+
+// indexedClient filters lists by the field indexes registered with it, which the fake client ignores. Like the cache
+// of a manager, it fails to list by a field that isn't indexed.
+type indexedClient struct {
+	client.Client
+	indexes map[string]client.IndexerFunc
+}
+
+func newIndexedClient(t *testing.T, c client.Client) *indexedClient {
+	ic := &indexedClient{Client: c, indexes: map[string]client.IndexerFunc{}}
+	assert.NoError(t, controller.AddIndexes(ic))
+	return ic
+}
+
+func (c *indexedClient) IndexField(_ runtime.Object, field string, extractValue client.IndexerFunc) error {
+	c.indexes[field] = extractValue
+	return nil
+}
+
+func (c *indexedClient) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	if listOpts.FieldSelector == nil {
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	matching := []runtime.Object{}
+	for _, item := range items {
+		values := fields.Set{}
+		for _, r := range listOpts.FieldSelector.Requirements() {
+			extractValue, ok := c.indexes[r.Field]
+			if !ok {
+				return fmt.Errorf("index with name field:%s does not exist", r.Field)
+			}
+			if v := extractValue(item); len(v) > 0 {
+				values[r.Field] = v[0]
+			}
+		}
+		if listOpts.FieldSelector.Matches(values) {
+			matching = append(matching, item)
+		}
+	}
+	return meta.SetList(list, matching)
+}
+
+func TestReconcile(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, kudov1beta1.AddToScheme(s))
+
+	operatorVersion := func(namespace, operator, version string) *kudov1beta1.OperatorVersion {
+		return &kudov1beta1.OperatorVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: operator + "-" + version, Namespace: namespace},
+			Spec: kudov1beta1.OperatorVersionSpec{
+				Operator:   corev1.ObjectReference{Name: operator},
+				Version:    version,
+				AppVersion: "2.4.0",
+			},
+		}
+	}
+	instance := func(namespace, name, ovNamespace, ovName string) *kudov1beta1.Instance {
+		return &kudov1beta1.Instance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: kudov1beta1.InstanceSpec{
+				OperatorVersion: corev1.ObjectReference{Name: ovName, Namespace: ovNamespace},
+			},
+		}
+	}
+
+	operator := &kudov1beta1.Operator{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "default"}}
+	key := types.NamespacedName{Namespace: "default", Name: "kafka"}
+	c := fakeclient.NewFakeClientWithScheme(s,
+		operator,
+		operatorVersion("default", "kafka", "0.10.0"),
+		operatorVersion("default", "kafka", "0.9.0"),
+		operatorVersion("default", "kafka", "1.0.0-rc1"),
+		operatorVersion("default", "zookeeper", "3.0.0"),
+		operatorVersion("other", "kafka", "2.0.0"),
+		instance("default", "kafka", "", "kafka-0.9.0"),
+		instance("default", "kafka-2", "", "kafka-0.10.0"),
+		instance("team", "kafka", "default", "kafka-0.9.0"),
+		instance("other", "kafka", "", "kafka-0.9.0"),
+		instance("default", "zk", "", "zookeeper-3.0.0"),
+	)
+	r := &Reconciler{Client: newIndexedClient(t, c), Log: log.NullLogger{}}
+
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+
+	operator = &kudov1beta1.Operator{}
+	assert.NoError(t, c.Get(context.TODO(), key, operator))
+	assert.Equal(t, kudov1beta1.OperatorStatus{
+		Versions: []kudov1beta1.InstalledOperatorVersion{
+			{Name: "kafka-0.9.0", Version: "0.9.0", AppVersion: "2.4.0", Instances: 2},
+			{Name: "kafka-0.10.0", Version: "0.10.0", AppVersion: "2.4.0", Instances: 1},
+			{Name: "kafka-1.0.0-rc1", Version: "1.0.0-rc1", AppVersion: "2.4.0", Instances: 0},
+		},
+		LatestVersion:     "1.0.0-rc1",
+		Instances:         3,
+		OutdatedInstances: 3,
+	}, operator.Status)
+}
+
+func TestReconcile_NoVersions(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, kudov1beta1.AddToScheme(s))
+
+	operator := &kudov1beta1.Operator{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "default"},
+		Status: kudov1beta1.OperatorStatus{
+			Versions:      []kudov1beta1.InstalledOperatorVersion{{Name: "kafka-1.0.0", Version: "1.0.0", Instances: 1}},
+			LatestVersion: "1.0.0",
+			Instances:     1,
+		},
+	}
+	key := types.NamespacedName{Namespace: "default", Name: "kafka"}
+	c := fakeclient.NewFakeClientWithScheme(s, operator)
+	r := &Reconciler{Client: c, Log: log.NullLogger{}}
+
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+
+	operator = &kudov1beta1.Operator{}
+	assert.NoError(t, c.Get(context.TODO(), key, operator))
+	assert.Equal(t, kudov1beta1.OperatorStatus{}, operator.Status)
+}
+
+func TestVersionLess(t *testing.T) {
+	tests := []struct {
+		a, b     kudov1beta1.InstalledOperatorVersion
+		expected bool
+	}{
+		{kudov1beta1.InstalledOperatorVersion{Name: "a", Version: "0.9.0"}, kudov1beta1.InstalledOperatorVersion{Name: "b", Version: "0.10.0"}, true},
+		{kudov1beta1.InstalledOperatorVersion{Name: "a", Version: "1.0.0"}, kudov1beta1.InstalledOperatorVersion{Name: "b", Version: "1.0.0-rc1"}, false},
+		{kudov1beta1.InstalledOperatorVersion{Name: "a", Version: "latest"}, kudov1beta1.InstalledOperatorVersion{Name: "b", Version: "0.1.0"}, true},
+		{kudov1beta1.InstalledOperatorVersion{Name: "a", Version: "0.1.0"}, kudov1beta1.InstalledOperatorVersion{Name: "b", Version: "latest"}, false},
+		{kudov1beta1.InstalledOperatorVersion{Name: "a", Version: "1.0"}, kudov1beta1.InstalledOperatorVersion{Name: "b", Version: "1.0.0"}, true},
+		{kudov1beta1.InstalledOperatorVersion{Name: "b", Version: "1.0"}, kudov1beta1.InstalledOperatorVersion{Name: "a", Version: "1.0.0"}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, versionLess(tt.a, tt.b), "%s (%s) < %s (%s)", tt.a.Name, tt.a.Version, tt.b.Name, tt.b.Version)
+	}
+}
@@ -17,33 +17,45 @@ package operatorversion
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/verify"
 )
 
 // Reconciler reconciles an OperatorVersion object
 type Reconciler struct {
 	client.Client
+	Log logr.Logger
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles, defaults to 1
+	MaxConcurrentReconciles int
 }
 
 // SetupWithManager registers this reconciler with the controller manager
 func (r *Reconciler) SetupWithManager(
 	mgr ctrl.Manager) error {
+	if r.Log == nil {
+		r.Log = ctrl.Log.WithName("controllers").WithName("operatorversion")
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kudov1beta1.OperatorVersion{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
 
-// Reconcile reads that state of the cluster for an OperatorVersion object and makes changes based on the state read
-// and what is in the OperatorVersion.Spec.
-//
-// Automatically generate RBAC rules to allow the Controller to read and write Deployments
+// Reconcile verifies an OperatorVersion with the same checks as 'kudo package verify' and records the result in its
+// status. The instance controller doesn't start plans of invalid operator versions.
 func (r *Reconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 	// Fetch the operator version
 	operatorVersion := &kudov1beta1.OperatorVersion{}
@@ -58,8 +70,51 @@ func (r *Reconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	log.Printf("OperatorVersionController: Received Reconcile request for an operatorVersion named: %v", request.Name)
+	log := r.Log.WithValues("namespace", request.Namespace, "operatorVersion", request.Name)
+	log.V(1).Info("received reconcile request")
+
+	oldStatus := operatorVersion.Status.DeepCopy()
+	updateStatus(operatorVersion)
+	if reflect.DeepEqual(&operatorVersion.Status, oldStatus) {
+		return reconcile.Result{}, nil
+	}
 
-	// TODO: Validate OperatorVersion is appropriate.
+	if len(operatorVersion.Status.Errors) > 0 {
+		log.Info("operator version is invalid", "errors", operatorVersion.Status.Errors)
+	}
+	if err := r.Status().Update(context.TODO(), operatorVersion); err != nil {
+		log.Error(err, "failed to update operator version status")
+		return reconcile.Result{}, err
+	}
 	return reconcile.Result{}, nil
 }
+
+// updateStatus verifies the operator version and sets the errors, warnings and the Valid condition of its status.
+// Errors and warnings are sorted, as some verifiers iterate maps and the status must not change on every reconcile.
+func updateStatus(ov *kudov1beta1.OperatorVersion) {
+	res := verify.OperatorVersion(ov)
+	sort.Strings(res.Errors)
+	sort.Strings(res.Warnings)
+	ov.Status.Errors = nil
+	ov.Status.Warnings = nil
+	if len(res.Errors) > 0 {
+		ov.Status.Errors = res.Errors
+	}
+	if len(res.Warnings) > 0 {
+		ov.Status.Warnings = res.Warnings
+	}
+
+	valid := kudov1beta1.Condition{
+		Type:               kudov1beta1.OperatorVersionConditionValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ov.Generation,
+		Reason:             kudov1beta1.OperatorVersionReasonVerified,
+		Message:            "operator version verified successfully",
+	}
+	if !res.IsValid() {
+		valid.Status = metav1.ConditionFalse
+		valid.Reason = kudov1beta1.OperatorVersionReasonVerificationFailed
+		valid.Message = fmt.Sprintf("operator version has %d error(s): %s", len(res.Errors), strings.Join(res.Errors, "; "))
+	}
+	kudov1beta1.SetCondition(&ov.Status.Conditions, valid)
+}
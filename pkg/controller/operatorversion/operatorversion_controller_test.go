@@ -0,0 +1,72 @@
+package operatorversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+)
+
+func TestReconcile(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, kudov1beta1.AddToScheme(s))
+
+	ov := &kudov1beta1.OperatorVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-1.0", Namespace: "default", Generation: 1},
+		Spec: kudov1beta1.OperatorVersionSpec{
+			Templates: map[string]string{"deployment.yaml": "replicas: {{ .Params.REPLICAS }}"},
+			Tasks: []kudov1beta1.Task{
+				{Name: "app", Kind: "Apply", Spec: kudov1beta1.TaskSpec{ResourceTaskSpec: kudov1beta1.ResourceTaskSpec{Resources: []string{"deployment.yaml"}}}},
+				{Name: "unknown", Kind: "Unknown"},
+			},
+			Plans: map[string]kudov1beta1.Plan{"deploy": {Phases: []kudov1beta1.Phase{{Name: "deploy", Steps: []kudov1beta1.Step{{Name: "app", Tasks: []string{"app"}}}}}}},
+		},
+	}
+	key := types.NamespacedName{Namespace: "default", Name: "foo-1.0"}
+	c := fakeclient.NewFakeClientWithScheme(s, ov)
+	r := &Reconciler{Client: c, Log: log.NullLogger{}}
+
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Get(context.TODO(), key, ov))
+	valid := ov.ValidCondition()
+	if assert.NotNil(t, valid) {
+		assert.Equal(t, metav1.ConditionFalse, valid.Status)
+		assert.Equal(t, kudov1beta1.OperatorVersionReasonVerificationFailed, valid.Reason)
+		assert.Equal(t, `operator version has 2 error(s): parameter "REPLICAS" in template deployment.yaml is not defined; task "unknown" is invalid: unknown task kind Unknown`, valid.Message)
+	}
+	assert.Equal(t, []string{
+		`parameter "REPLICAS" in template deployment.yaml is not defined`,
+		`task "unknown" is invalid: unknown task kind Unknown`,
+	}, ov.Status.Errors)
+	assert.Nil(t, ov.Status.Warnings)
+
+	ov.Generation = 2
+	ov.Spec.Parameters = []kudov1beta1.Parameter{{Name: "REPLICAS"}}
+	ov.Spec.Tasks = ov.Spec.Tasks[:1]
+	assert.NoError(t, c.Update(context.TODO(), ov))
+	assert.Nil(t, ov.ValidCondition(), "the new generation was not verified yet")
+
+	_, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+
+	ov = &kudov1beta1.OperatorVersion{}
+	assert.NoError(t, c.Get(context.TODO(), key, ov))
+	valid = ov.ValidCondition()
+	if assert.NotNil(t, valid) {
+		assert.Equal(t, metav1.ConditionTrue, valid.Status)
+		assert.Equal(t, kudov1beta1.OperatorVersionReasonVerified, valid.Reason)
+		assert.Equal(t, int64(2), valid.ObservedGeneration)
+	}
+	assert.Nil(t, ov.Status.Errors)
+	assert.Len(t, ov.Status.Conditions, 1)
+}
@@ -3,21 +3,28 @@ package health
 import (
 	"errors"
 	"fmt"
-	"log"
 	"reflect"
 
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/kubectl/pkg/polymorphichelpers"
 
 	kudov1beta1 "github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 )
 
-// IsHealthy returns whether an object is healthy. Must be implemented for each type.
-func IsHealthy(obj runtime.Object) error {
+// CRDGroupKind is the GroupKind of CustomResourceDefinitions. CRDs are not part of the default scheme and are therefore
+// often handled as unstructured objects
+var CRDGroupKind = schema.GroupKind{Group: apiextv1beta1.GroupName, Kind: "CustomResourceDefinition"}
+
+// IsHealthy returns whether an object is healthy. Must be implemented for each type. The result of the check is logged
+// to the passed logger.
+func IsHealthy(obj runtime.Object, log logr.Logger) error {
 	if obj == nil {
 		return nil
 	}
@@ -35,10 +42,10 @@ func IsHealthy(obj runtime.Object) error {
 			return err
 		}
 		if !done {
-			log.Printf("HealthUtil: Statefulset %v is NOT healthy. %s", obj.Name, msg)
+			log.Info("statefulset is not healthy", "name", obj.Name, "status", msg)
 			return errors.New(msg)
 		}
-		log.Printf("Statefulset %v is marked healthy\n", obj.Name)
+		log.V(1).Info("statefulset is healthy", "name", obj.Name)
 		return nil
 	case *appsv1.Deployment:
 		statusViewer := &polymorphichelpers.DeploymentStatusViewer{}
@@ -47,27 +54,36 @@ func IsHealthy(obj runtime.Object) error {
 			return err
 		}
 		if !done {
-			log.Printf("HealthUtil: Deployment %v is NOT healthy. %s", obj.Name, msg)
+			log.Info("deployment is not healthy", "name", obj.Name, "status", msg)
 			return errors.New(msg)
 		}
-		log.Printf("Deployment %v is marked healthy\n", obj.Name)
+		log.V(1).Info("deployment is healthy", "name", obj.Name)
 		return nil
 	case *batchv1.Job:
 
 		if obj.Status.Succeeded == int32(1) {
 			// Done!
-			log.Printf("HealthUtil: Job \"%v\" is marked healthy", obj.Name)
+			log.V(1).Info("job is healthy", "name", obj.Name)
 			return nil
 		}
 		return fmt.Errorf("job \"%v\" still running or failed", obj.Name)
 	case *kudov1beta1.Instance:
-		log.Printf("HealthUtil: Instance %v is in state %v", obj.Name, obj.Status.AggregatedStatus.Status)
+		log.V(1).Info("checking instance health", "name", obj.Name, "status", obj.Status.AggregatedStatus.Status)
 
 		if obj.Status.AggregatedStatus.Status.IsFinished() {
 			return nil
 		}
 		return fmt.Errorf("instance's active plan is in state %v", obj.Status.AggregatedStatus.Status)
 
+	case *apiextv1beta1.CustomResourceDefinition:
+		return isEstablished(objUnstructured, log)
+	case *unstructured.Unstructured:
+		if obj.GroupVersionKind().GroupKind() == CRDGroupKind {
+			return isEstablished(obj, log)
+		}
+		log.V(1).Info("unknown type is healthy by default", "kind", obj.GroupVersionKind().String(), "name", obj.GetName())
+		return nil
+
 	case *corev1.Pod:
 		if obj.Status.Phase == corev1.PodRunning {
 			return nil
@@ -76,7 +92,27 @@ func IsHealthy(obj runtime.Object) error {
 
 	// unless we build logic for what a healthy object is, assume it's healthy when created.
 	default:
-		log.Printf("HealthUtil: Unknown type %s is marked healthy by default", reflect.TypeOf(obj))
+		log.V(1).Info("unknown type is healthy by default", "type", reflect.TypeOf(obj).String())
 		return nil
 	}
 }
+
+// isEstablished checks the Established condition of a CustomResourceDefinition. Custom resources of a CRD can only be
+// created once it is established.
+func isEstablished(crd *unstructured.Unstructured, log logr.Logger) error {
+	conditions, _, err := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	if err != nil {
+		return err
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == string(apiextv1beta1.Established) && condition["status"] == string(apiextv1beta1.ConditionTrue) {
+			log.V(1).Info("CRD is established", "name", crd.GetName())
+			return nil
+		}
+	}
+	return fmt.Errorf("CRD %v is not established yet", crd.GetName())
+}
@@ -0,0 +1,109 @@
+package renderer
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/pkg/fs"
+	"sigs.k8s.io/kustomize/pkg/gvk"
+	apipatch "sigs.k8s.io/kustomize/pkg/patch"
+	ktypes "sigs.k8s.io/kustomize/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+)
+
+// resourceID identifies a rendered resource for patching
+type resourceID struct {
+	gvk  schema.GroupVersionKind
+	name string
+}
+
+// customize merges the instance customization into the kustomization. Common labels and annotations are added unless
+// they would override KUDO ones. Patches are written to the kustomize file system next to the templates. Since an
+// instance customization applies to all tasks of all plans, only patches targeting one of the passed templates
+// resources are added: kustomize fails on patches without a target.
+func customize(kustomization *ktypes.Kustomization, fsys fs.FileSystem, templates map[string]string, c *v1beta1.InstanceCustomization) error {
+	if c == nil {
+		return nil
+	}
+
+	for k, v := range c.CommonLabels {
+		if _, ok := kustomization.CommonLabels[k]; !ok {
+			kustomization.CommonLabels[k] = v
+		}
+	}
+	for k, v := range c.CommonAnnotations {
+		if _, ok := kustomization.CommonAnnotations[k]; !ok {
+			kustomization.CommonAnnotations[k] = v
+		}
+	}
+
+	if len(c.PatchesStrategicMerge) == 0 && len(c.PatchesJSON6902) == 0 {
+		return nil
+	}
+	ids, err := resourceIDs(templates)
+	if err != nil {
+		return err
+	}
+
+	for i, p := range c.PatchesStrategicMerge {
+		var head struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(p), &head); err != nil {
+			return fmt.Errorf("error parsing strategic merge patch %d: %w", i, err)
+		}
+		id := resourceID{gvk: schema.FromAPIVersionAndKind(head.APIVersion, head.Kind), name: head.Metadata.Name}
+		if !ids[id] {
+			continue
+		}
+
+		path := fmt.Sprintf("patches/strategic-merge-%d.yaml", i)
+		if err := fsys.WriteFile(fmt.Sprintf("%s/%s", basePath, path), []byte(p)); err != nil {
+			return fmt.Errorf("error writing strategic merge patch %d: %w", i, err)
+		}
+		kustomization.PatchesStrategicMerge = append(kustomization.PatchesStrategicMerge, apipatch.StrategicMerge(path))
+	}
+
+	for i, p := range c.PatchesJSON6902 {
+		t := p.Target
+		id := resourceID{gvk: schema.GroupVersionKind{Group: t.Group, Version: t.Version, Kind: t.Kind}, name: t.Name}
+		if !ids[id] {
+			continue
+		}
+
+		path := fmt.Sprintf("patches/json6902-%d.yaml", i)
+		if err := fsys.WriteFile(fmt.Sprintf("%s/%s", basePath, path), []byte(p.Patch)); err != nil {
+			return fmt.Errorf("error writing JSON 6902 patch %d: %w", i, err)
+		}
+		kustomization.PatchesJson6902 = append(kustomization.PatchesJson6902, apipatch.Json6902{
+			Target: &apipatch.Target{Gvk: gvk.Gvk{Group: t.Group, Version: t.Version, Kind: t.Kind}, Name: t.Name},
+			Path:   path,
+		})
+	}
+	return nil
+}
+
+// resourceIDs returns the IDs of all resources in the passed templates
+func resourceIDs(templates map[string]string) (map[resourceID]bool, error) {
+	ids := map[resourceID]bool{}
+	for name, t := range templates {
+		objs, err := YamlToObject(t)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template %s: %w", name, err)
+		}
+		for _, o := range objs {
+			accessor, ok := o.(interface{ GetName() string })
+			if !ok {
+				continue
+			}
+			ids[resourceID{gvk: o.GetObjectKind().GroupVersionKind(), name: accessor.GetName()}] = true
+		}
+	}
+	return ids, nil
+}
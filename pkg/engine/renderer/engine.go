@@ -2,7 +2,10 @@ package renderer
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig"
@@ -26,11 +29,22 @@ type Metadata struct {
 // Engine is the control struct for parsing and templating Kubernetes resources in an ordered fashion
 type Engine struct {
 	FuncMap template.FuncMap
+	// Partials are templates containing only named template definitions which can be used from any rendered template
+	// with the "template" action or the "include" function
+	Partials map[string]string
+}
+
+// IsPartial returns true if a template with the given name is a partial and not a resource template. Partials are
+// template files prefixed with an underscore and with a ".tpl" extension, like "_helpers.tpl". Both the package reader
+// and the engine use it, so that e.g. "_service.yaml" is read and rendered as a resource template.
+func IsPartial(name string) bool {
+	base := filepath.Base(name)
+	return strings.HasPrefix(base, "_") && strings.HasSuffix(base, ".tpl")
 }
 
 // New creates an engine with a default function map, using a modified Sprig func map. Because these
 // templates are rendered by the operator, we delete any functions that potentially access the environment
-// the controller is running in.
+// the controller is running in. KUDO specific functions like "toYaml" or "required" are added on top.
 func New() *Engine {
 	f := sprig.TxtFuncMap()
 
@@ -41,6 +55,16 @@ func New() *Engine {
 		delete(f, fun)
 	}
 
+	for name, fun := range kudoFuncMap() {
+		f[name] = fun
+	}
+
+	// "include" needs access to the parsed templates and is therefore replaced during rendering. We still add it here
+	// so that templates using it can be parsed on their own e.g. when verifying packages
+	f["include"] = func(string, interface{}) (string, error) {
+		return "", errors.New("include is not available outside of rendering")
+	}
+
 	return &Engine{
 		FuncMap: f,
 	}
@@ -56,15 +80,38 @@ func (e Engine) Template(name string) *template.Template {
 
 // Render creates a fully rendered template based on a set of values. It parses these in strict mode,
 // returning errors when keys are missing.
+// Named templates defined in the engine partials can be used in the rendered template.
 func (e *Engine) Render(tpl string, vals map[string]interface{}) (string, error) {
 	var buf bytes.Buffer
 	t := e.Template("tpl")
 
+	// include is similar to the "template" action but returns the rendered template as a string so it can be piped
+	// e.g. {{ include "labels" . | indent 4 }}
+	t.Funcs(template.FuncMap{
+		"include": func(name string, data interface{}) (string, error) {
+			var out bytes.Buffer
+			if err := t.ExecuteTemplate(&out, name, data); err != nil {
+				return "", err
+			}
+			return out.String(), nil
+		},
+	})
+
+	for name, partial := range e.Partials {
+		if _, err := t.New(name).Parse(partial); err != nil {
+			return "", fmt.Errorf("error parsing partial %s: %s", name, err)
+		}
+	}
+
 	if _, err := t.Parse(tpl); err != nil {
 		return "", fmt.Errorf("error parsing template: %s", err)
 	}
 
 	if err := t.ExecuteTemplate(&buf, "tpl", vals); err != nil {
+		var templateErr *TemplateError
+		if errors.As(err, &templateErr) {
+			return "", fmt.Errorf("error rendering template: %w", templateErr)
+		}
 		return "", fmt.Errorf("error rendering template: %s", err)
 	}
 
@@ -62,3 +62,49 @@ func TestUnsafeFuncs(t *testing.T) {
 	}
 
 }
+
+func TestRender_Partials(t *testing.T) {
+	engine := New()
+	engine.Partials = map[string]string{
+		"_helpers.tpl": `{{ define "labels" }}app: {{ .Name }}{{ end }}`,
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{name: "template action", template: `{{ template "labels" . }}`, expected: "app: zk"},
+		{name: "include", template: `{{ include "labels" . | upper }}`, expected: "APP: ZK"},
+	}
+
+	for _, test := range tests {
+		rendered, err := engine.Render(test.template, map[string]interface{}{"Name": "zk"})
+		if err != nil {
+			t.Errorf("%s: error rendering template: %s", test.name, err)
+		}
+		if rendered != test.expected {
+			t.Errorf("%s: template mismatch, expected: %+v, got: %+v", test.name, test.expected, rendered)
+		}
+	}
+
+	if _, err := engine.Render(`{{ include "missing" . }}`, map[string]interface{}{}); err == nil {
+		t.Errorf("expected an error when including an undefined template")
+	}
+}
+
+func TestIsPartial(t *testing.T) {
+	tests := map[string]bool{
+		"_helpers.tpl":                        true,
+		"charts/redis/templates/_helpers.tpl": true,
+		"_service.yaml":                       false,
+		"helpers.tpl":                         false,
+		"service.yaml":                        false,
+	}
+
+	for name, expected := range tests {
+		if IsPartial(name) != expected {
+			t.Errorf("%s: expected IsPartial to be %v", name, expected)
+		}
+	}
+}
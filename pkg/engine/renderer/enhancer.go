@@ -1,13 +1,16 @@
 package renderer
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
-	"log"
 
+	"github.com/go-logr/logr"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/kustomize/k8sdeps/kunstruct"
 	"sigs.k8s.io/kustomize/k8sdeps/transformer"
 	"sigs.k8s.io/kustomize/pkg/fs"
@@ -18,6 +21,7 @@ import (
 	"sigs.k8s.io/kustomize/pkg/target"
 	ktypes "sigs.k8s.io/kustomize/pkg/types"
 
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
@@ -33,6 +37,10 @@ type Enhancer interface {
 // KustomizeEnhancer is implementation of Enhancer that uses kustomize to apply the defined conventions
 type KustomizeEnhancer struct {
 	Scheme *runtime.Scheme
+	// RegistryMirrors maps container image registries to their mirrors, see mirror for details
+	RegistryMirrors map[string]string
+	// Log receives debug messages about the enhanced resources, they are discarded if unset
+	Log logr.Logger
 }
 
 // Apply accepts templates to be rendered in kubernetes and enhances them with our own KUDO conventions
@@ -71,6 +79,19 @@ func (k *KustomizeEnhancer) Apply(templates map[string]string, metadata Metadata
 		PatchesStrategicMerge: []apipatch.StrategicMerge{},
 	}
 
+	if err := customize(kustomization, fsys, templates, metadata.Customization); err != nil {
+		return nil, fmt.Errorf("error applying instance customization: %w", err)
+	}
+
+	var overrides []v1beta1.ImageOverride
+	if metadata.Customization != nil {
+		overrides = metadata.Customization.Images
+	}
+	kustomization.Images, err = images(templates, overrides, k.RegistryMirrors)
+	if err != nil {
+		return nil, fmt.Errorf("error rewriting container images: %w", err)
+	}
+
 	yamlBytes, err := yaml.Marshal(kustomization)
 	if err != nil {
 		return nil, fmt.Errorf("error marshalling kustomize yaml: %w", err)
@@ -113,16 +134,46 @@ func (k *KustomizeEnhancer) Apply(templates map[string]string, metadata Metadata
 	}
 
 	for _, o := range objsToAdd {
-		err = setControllerReference(metadata.ResourcesOwner, o, k.Scheme)
+		setNamespace(o)
+
+		err = setControllerReference(metadata.ResourcesOwner, o, k.Scheme, k.logger())
 		if err != nil {
 			return nil, fmt.Errorf("setting controller reference on parsed object: %w", err)
 		}
+
+		err = setContentHash(o)
+		if err != nil {
+			return nil, fmt.Errorf("setting content hash on parsed object: %w", err)
+		}
 	}
 
 	return objsToAdd, nil
 }
 
-func setControllerReference(owner v1.Object, obj runtime.Object, scheme *runtime.Scheme) error {
+// setNamespace overrides the namespace set by kustomize for objects annotated with the kudo.ClusterScopedAnnotation or
+// the kudo.TargetNamespaceAnnotation. Kustomize only knows a few built-in cluster-scoped kinds and puts every other
+// object into the instance namespace.
+func setNamespace(obj runtime.Object) {
+	object := obj.(v1.Object)
+	annotations := object.GetAnnotations()
+
+	if annotations[kudo.ClusterScopedAnnotation] == "true" {
+		object.SetNamespace("")
+		return
+	}
+	if ns, ok := annotations[kudo.TargetNamespaceAnnotation]; ok && ns != "" {
+		object.SetNamespace(ns)
+	}
+}
+
+func (k *KustomizeEnhancer) logger() logr.Logger {
+	if k.Log == nil {
+		return log.NullLogger{}
+	}
+	return k.Log
+}
+
+func setControllerReference(owner v1.Object, obj runtime.Object, scheme *runtime.Scheme, log logr.Logger) error {
 	object := obj.(v1.Object)
 	ownerNs := owner.GetNamespace()
 	if ownerNs != "" {
@@ -130,15 +181,17 @@ func setControllerReference(owner v1.Object, obj runtime.Object, scheme *runtime
 		if objNs == "" {
 			// we're trying to create cluster-scoped resource from and bind Instance as owner of that
 			// that is disallowed by design, see https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#owners-and-dependents
-			// for now solve by not adding the owner
-			log.Printf("Not adding owner to resource %s because it's cluster-scoped and cannot be owned by namespace-scoped instance %s/%s", object.GetName(), owner.GetNamespace(), owner.GetName())
+			// we track the resource with a label instead and delete it when the instance is deleted
+			log.V(1).Info("not adding owner to cluster-scoped resource", "resource", object.GetName())
+			setInstanceNamespaceLabel(object, ownerNs)
 			return nil
 		}
 		if ownerNs != objNs {
 			// we're trying to create resource in another namespace as is Instance's namespace, Instance cannot be owner of such resource
 			// that is disallowed by design, see https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#owners-and-dependents
-			// for now solve by not adding the owner
-			log.Printf("Not adding owner to resource %s/%s because it's in different namespace than instance %s/%s and thus cannot be owned by that instance", object.GetNamespace(), object.GetName(), owner.GetNamespace(), owner.GetName())
+			// we track the resource with a label instead and delete it when the instance is deleted
+			log.V(1).Info("not adding owner to resource in another namespace", "resourceNamespace", object.GetNamespace(), "resource", object.GetName())
+			setInstanceNamespaceLabel(object, ownerNs)
 			return nil
 		}
 	}
@@ -147,3 +200,39 @@ func setControllerReference(owner v1.Object, obj runtime.Object, scheme *runtime
 	}
 	return nil
 }
+
+// setInstanceNamespaceLabel sets the kudo.InstanceNamespaceLabel on resources that can not be owned by the instance.
+// Together with the common KUDO labels it allows to find and delete these resources once the instance is deleted. The
+// kinds of labeled resources are recorded on the instance by the tasks applying them.
+// Note that the label is only set on the object metadata and, unlike kustomize common labels, not on selectors.
+func setInstanceNamespaceLabel(object v1.Object, namespace string) {
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[kudo.InstanceNamespaceLabel] = namespace
+	object.SetLabels(labels)
+}
+
+// setContentHash stamps a hash of the fully enhanced object in the kudo.ContentHashAnnotation. An object's content
+// changes whenever the rendered template, the instance parameters or the plan execution change. Apply task compares
+// this hash with the one of the live object and can skip patching objects that are already up to date.
+func setContentHash(obj runtime.Object) error {
+	object := obj.(v1.Object)
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	delete(annotations, kudo.ContentHashAnnotation)
+	object.SetAnnotations(annotations)
+
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	annotations[kudo.ContentHashAnnotation] = fmt.Sprintf("%x", sha256.Sum256(content))
+	object.SetAnnotations(annotations)
+	return nil
+}
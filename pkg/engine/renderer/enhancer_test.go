@@ -0,0 +1,215 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/engine"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
+)
+
+func TestKustomizeEnhancer_Apply(t *testing.T) {
+	instance := &v1beta1.Instance{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "kudo.dev/v1beta1", Kind: "Instance"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+	}
+	meta := Metadata{
+		Metadata: engine.Metadata{
+			InstanceName:      "test",
+			InstanceNamespace: "default",
+			OperatorName:      "operator",
+			ResourcesOwner:    instance,
+		},
+		PlanName:  "deploy",
+		PhaseName: "phase",
+		StepName:  "step",
+	}
+
+	templates := map[string]string{
+		"pod.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+`,
+		"class.yaml": `apiVersion: scheduling.k8s.io/v1
+kind: PriorityClass
+metadata:
+  name: class
+  annotations:
+    kudo.dev/cluster-scoped: "true"
+value: 1000
+`,
+		"cm.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    kudo.dev/target-namespace: other
+`,
+	}
+
+	if err := v1beta1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+	objs, err := (&KustomizeEnhancer{Scheme: scheme.Scheme}).Apply(templates, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(objs))
+
+	for _, o := range objs {
+		object := o.(metav1.Object)
+		assert.NotEmpty(t, object.GetAnnotations()[kudo.ContentHashAnnotation], object.GetName())
+
+		switch object.GetName() {
+		case "pod":
+			assert.Equal(t, "default", object.GetNamespace())
+			assert.Equal(t, 1, len(object.GetOwnerReferences()))
+			assert.Empty(t, object.GetLabels()[kudo.InstanceNamespaceLabel])
+		case "class":
+			assert.Equal(t, "", object.GetNamespace())
+			assert.Equal(t, 0, len(object.GetOwnerReferences()))
+			assert.Equal(t, "default", object.GetLabels()[kudo.InstanceNamespaceLabel])
+		case "cm":
+			assert.Equal(t, "other", object.GetNamespace())
+			assert.Equal(t, 0, len(object.GetOwnerReferences()))
+			assert.Equal(t, "default", object.GetLabels()[kudo.InstanceNamespaceLabel])
+		default:
+			t.Errorf("unexpected object %s", object.GetName())
+		}
+	}
+
+	// the instance is not changed while rendering
+	assert.False(t, instance.HasResourcesFinalizer())
+	assert.Empty(t, instance.Annotations[kudo.UnownedResourcesAnnotation])
+}
+
+func TestKustomizeEnhancer_ApplyCustomization(t *testing.T) {
+	instance := &v1beta1.Instance{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "kudo.dev/v1beta1", Kind: "Instance"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+	}
+	meta := Metadata{
+		Metadata: engine.Metadata{
+			InstanceName:      "test",
+			InstanceNamespace: "default",
+			OperatorName:      "operator",
+			ResourcesOwner:    instance,
+			Customization: &v1beta1.InstanceCustomization{
+				CommonLabels:      map[string]string{"cost-center": "42", kudo.InstanceLabel: "override"},
+				CommonAnnotations: map[string]string{"team": "data"},
+				PatchesStrategicMerge: []string{`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      nodeSelector:
+        disk: ssd
+`, `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: not-rendered-in-this-task
+spec:
+  replicas: 3
+`},
+				PatchesJSON6902: []v1beta1.JSON6902Patch{{
+					Target: v1beta1.PatchTarget{Group: "apps", Version: "v1", Kind: "Deployment", Name: "app"},
+					Patch:  `[{"op": "replace", "path": "/spec/replicas", "value": 5}]`,
+				}},
+			},
+		},
+	}
+
+	templates := map[string]string{
+		"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+`,
+	}
+
+	if err := v1beta1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+	objs, err := (&KustomizeEnhancer{Scheme: scheme.Scheme}).Apply(templates, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(objs))
+
+	deployment := objs[0].(*appsv1.Deployment)
+	assert.Equal(t, "42", deployment.Labels["cost-center"])
+	assert.Equal(t, "test", deployment.Labels[kudo.InstanceLabel], "KUDO labels can not be overridden")
+	assert.Equal(t, "data", deployment.Annotations["team"])
+	assert.Equal(t, map[string]string{"disk": "ssd"}, deployment.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, int32(5), *deployment.Spec.Replicas)
+}
+
+func TestKustomizeEnhancer_ApplyImages(t *testing.T) {
+	instance := &v1beta1.Instance{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "kudo.dev/v1beta1", Kind: "Instance"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+	}
+	meta := Metadata{
+		Metadata: engine.Metadata{
+			InstanceName:      "test",
+			InstanceNamespace: "default",
+			OperatorName:      "operator",
+			ResourcesOwner:    instance,
+			Customization: &v1beta1.InstanceCustomization{
+				Images: []v1beta1.ImageOverride{{Name: "quay.io/org/app", NewTag: "2.0"}, {Name: "nginx", Digest: "sha256:def"}},
+			},
+		},
+	}
+
+	templates := map[string]string{
+		"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox:1.31
+      containers:
+      - name: app
+        image: quay.io/org/app:1.0
+      - name: sidecar
+        image: gcr.io/project/sidecar:1.0
+      - name: db
+        image: quay.io/org/db@sha256:abc
+      - name: proxy
+        image: nginx:1.17@sha256:abc
+`,
+	}
+
+	if err := v1beta1.AddToScheme(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+	enhancer := &KustomizeEnhancer{
+		Scheme:          scheme.Scheme,
+		RegistryMirrors: map[string]string{"docker.io": "mirror.corp/dockerhub", "quay.io": "mirror.corp/quay/"},
+	}
+	objs, err := enhancer.Apply(templates, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(objs))
+
+	spec := objs[0].(*appsv1.Deployment).Spec.Template.Spec
+	assert.Equal(t, "mirror.corp/dockerhub/library/busybox:1.31", spec.InitContainers[0].Image)
+	assert.Equal(t, "mirror.corp/quay/org/app:2.0", spec.Containers[0].Image)
+	assert.Equal(t, "gcr.io/project/sidecar:1.0", spec.Containers[1].Image, "images of registries without mirror are not changed")
+	assert.Equal(t, "mirror.corp/quay/org/db@sha256:abc", spec.Containers[2].Image, "images pinned by digest are mirrored")
+	assert.Equal(t, "mirror.corp/dockerhub/library/nginx@sha256:def", spec.Containers[3].Image)
+}
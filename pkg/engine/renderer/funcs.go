@@ -0,0 +1,121 @@
+package renderer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"text/template"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// TemplateError is returned by the "required" and "fail" template functions. Its message is provided by the
+// operator developer and therefore surfaced as is, without the template execution context.
+type TemplateError struct {
+	Message string
+}
+
+func (e *TemplateError) Error() string {
+	return e.Message
+}
+
+// kudoFuncMap returns KUDO specific template functions which are added to the sprig functions
+func kudoFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"toYaml":   toYaml,
+		"fromYaml": fromYaml,
+		"required": required,
+		"fail":     fail,
+		// lookup needs a client and is therefore only available when templates are rendered by the controller, see Lookup
+		"lookup": func(string, string, string) (map[string]interface{}, error) {
+			return nil, fmt.Errorf("lookup is not available outside of the KUDO controller")
+		},
+	}
+}
+
+// toYaml marshals the passed value to YAML e.g. {{ .Params.RESOURCES | toYaml | indent 4 }}
+func toYaml(v interface{}) (string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %v to YAML: %w", v, err)
+	}
+	return string(b), nil
+}
+
+// fromYaml unmarshals the passed YAML string into a map e.g. {{ (fromYaml .Params.CONFIG).key }}
+func fromYaml(s string) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(s), &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
+	}
+	return m, nil
+}
+
+// required fails the rendering with the given message if the value is nil or an empty string,
+// e.g. {{ required "PASSWORD parameter must be set" .Params.PASSWORD }}
+func required(msg string, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, &TemplateError{Message: msg}
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.String && rv.Len() == 0 {
+		return nil, &TemplateError{Message: msg}
+	}
+	return v, nil
+}
+
+// fail unconditionally fails the rendering with the given message, e.g. {{ if lt .Params.NODES 3 }}{{ fail "at least 3 nodes are needed" }}{{ end }}
+func fail(msg string) (string, error) {
+	return "", &TemplateError{Message: msg}
+}
+
+// Lookup returns a read-only "lookup" template function which fetches an existing object from the cluster using the
+// passed client, e.g. {{ (lookup "Secret" .Namespace "credentials").data.password }}. Only objects in the given
+// namespace or cluster-scoped objects can be retrieved: an empty namespace defaults to the given one, any other
+// namespace is rejected. The scope of a kind is resolved with the passed mapper and the namespace is dropped for
+// cluster-scoped kinds, e.g. {{ lookup "PriorityClass.v1.scheduling.k8s.io" "" "high" }}. Without a mapper, all
+// kinds are looked up in the given namespace.
+//
+// Kinds of the core group are used as is (e.g. "ConfigMap"), all other kinds have to be qualified with their version
+// and group like "Deployment.v1.apps", the same notation kubectl uses. If the object does not exist, an empty map
+// is returned so that templates can check for its existence with "if".
+func Lookup(c client.Client, mapper meta.RESTMapper, namespace string) func(string, string, string) (map[string]interface{}, error) {
+	return func(kind, ns, name string) (map[string]interface{}, error) {
+		if ns == "" {
+			ns = namespace
+		}
+		if ns != namespace {
+			return nil, &TemplateError{Message: fmt.Sprintf("lookup of %s %s/%s is not allowed: only objects in namespace %s can be looked up", kind, ns, name, namespace)}
+		}
+
+		gvk, gk := schema.ParseKindArg(kind)
+		if gvk == nil {
+			gvk = &schema.GroupVersionKind{Group: gk.Group, Version: "v1", Kind: gk.Kind}
+		}
+
+		if mapper != nil {
+			mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up %s %s/%s: %w", kind, ns, name, err)
+			}
+			if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+				ns = ""
+			}
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(*gvk)
+		err := c.Get(context.TODO(), client.ObjectKey{Namespace: ns, Name: name}, obj)
+		switch {
+		case apierrors.IsNotFound(err):
+			return map[string]interface{}{}, nil
+		case err != nil:
+			return nil, fmt.Errorf("failed to look up %s %s/%s: %w", kind, ns, name, err)
+		}
+		return obj.Object, nil
+	}
+}
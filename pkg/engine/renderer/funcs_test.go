@@ -0,0 +1,97 @@
+package renderer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRender_Funcs(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		params   map[string]interface{}
+		expected string
+		err      string
+	}{
+		{name: "toYaml", template: `{{ .Params.Map | toYaml }}`, params: map[string]interface{}{"Map": map[string]interface{}{"a": "b"}}, expected: "a: b\n"},
+		{name: "fromYaml", template: `{{ (fromYaml .Params.Yaml).a }}`, params: map[string]interface{}{"Yaml": "a: b"}, expected: "b"},
+		{name: "required value set", template: `{{ required "Foo is required" .Params.Foo }}`, params: map[string]interface{}{"Foo": "bar"}, expected: "bar"},
+		{name: "required value empty", template: `{{ required "Foo is required" .Params.Foo }}`, params: map[string]interface{}{"Foo": ""}, err: "error rendering template: Foo is required"},
+		{name: "fail", template: `{{ if .Params.Foo }}{{ fail "Foo is not supported" }}{{ end }}`, params: map[string]interface{}{"Foo": "bar"}, err: "error rendering template: Foo is not supported"},
+		{name: "lookup without client", template: `{{ lookup "ConfigMap" "" "cm" }}`, err: "lookup is not available outside of the KUDO controller"},
+	}
+
+	for _, tt := range tests {
+		rendered, err := New().Render(tt.template, map[string]interface{}{"Params": tt.params})
+		if tt.err != "" {
+			assert.Error(t, err, tt.name)
+			assert.Contains(t, err.Error(), tt.err, tt.name)
+			continue
+		}
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, rendered, tt.name)
+	}
+}
+
+func TestRender_TemplateError(t *testing.T) {
+	_, err := New().Render(`{{ fail "boom" }}`, map[string]interface{}{})
+
+	var templateErr *TemplateError
+	assert.True(t, errors.As(err, &templateErr))
+	assert.Equal(t, "boom", templateErr.Message)
+}
+
+func TestLookup(t *testing.T) {
+	c := fake.NewFakeClientWithScheme(scheme.Scheme,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"}, Data: map[string]string{"foo": "bar"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "other"}},
+	)
+
+	e := New()
+	e.FuncMap["lookup"] = Lookup(c, nil, "default")
+
+	rendered, err := e.Render(`{{ (lookup "ConfigMap" "" "cm").data.foo }}`, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", rendered)
+
+	rendered, err = e.Render(`{{ if lookup "ConfigMap.v1." "default" "missing" }}found{{ else }}missing{{ end }}`, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "missing", rendered)
+
+	_, err = e.Render(`{{ lookup "ConfigMap" "other" "cm" }}`, map[string]interface{}{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only objects in namespace default can be looked up")
+}
+
+func TestLookup_ClusterScoped(t *testing.T) {
+	c := fake.NewFakeClientWithScheme(scheme.Scheme,
+		&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high"}, Value: 1000},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"}, Data: map[string]string{"foo": "bar"}},
+	)
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schedulingv1.SchemeGroupVersion.WithKind("PriorityClass"), meta.RESTScopeRoot)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+
+	e := New()
+	e.FuncMap["lookup"] = Lookup(c, mapper, "default")
+
+	rendered, err := e.Render(`{{ (lookup "PriorityClass.v1.scheduling.k8s.io" "" "high").value }}`, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", rendered)
+
+	rendered, err = e.Render(`{{ (lookup "ConfigMap" "default" "cm").data.foo }}`, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", rendered)
+
+	_, err = e.Render(`{{ lookup "Unknown.v1.example.com" "" "foo" }}`, map[string]interface{}{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to look up Unknown.v1.example.com default/foo")
+}
@@ -0,0 +1,212 @@
+package renderer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/kustomize/pkg/image"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+)
+
+const dockerHub = "docker.io"
+
+// ContainerImages returns the sorted, unique images of all containers and init containers in the passed objects.
+// Like the kustomize images transformer, it looks for containers anywhere in an object, so custom resources
+// embedding pod templates are covered as well.
+func ContainerImages(objs []runtime.Object) ([]string, error) {
+	found := map[string]bool{}
+	for _, o := range objs {
+		content, err := toUnstructured(o)
+		if err != nil {
+			return nil, err
+		}
+		collectImages(content, found)
+	}
+
+	images := make([]string, 0, len(found))
+	for i := range found {
+		images = append(images, i)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+func toUnstructured(o runtime.Object) (map[string]interface{}, error) {
+	if u, ok := o.(*unstructured.Unstructured); ok {
+		return u.UnstructuredContent(), nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, fmt.Errorf("error converting %s: %w", o.GetObjectKind().GroupVersionKind().Kind, err)
+	}
+	return content, nil
+}
+
+func collectImages(obj map[string]interface{}, found map[string]bool) {
+	for key, value := range obj {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			collectImages(v, found)
+		case []interface{}:
+			for _, item := range v {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if key == "containers" || key == "initContainers" {
+					if i, ok := m["image"].(string); ok && i != "" {
+						found[i] = true
+					}
+				}
+				collectImages(m, found)
+			}
+		}
+	}
+}
+
+// splitImage separates an image into its name and its tag and/or digest, including the separators
+func splitImage(img string) (name, tag string) {
+	name = img
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name, img[len(name):]
+}
+
+// kustomizeSplit separates an image like the kustomize images transformer does: at the last colon after the
+// registry, so "app@sha256:abc" is split into "app@sha256" and ":abc".
+func kustomizeSplit(img string) (name, tag string) {
+	i := strings.LastIndex(img, ":")
+	if slash := strings.Index(img, "/"); slash >= 0 && i < slash {
+		i = -1
+	}
+	if i < 0 {
+		i = strings.LastIndex(img, "@")
+	}
+	if i < 0 {
+		return img, ""
+	}
+	return img[:i], img[i:]
+}
+
+// digestImage returns the kustomize image rewrite of an image pinned by a digest to the target image. The kustomize
+// images transformer only matches images by "name(:tag)?", so the rewrite matches the image exactly, as it is split
+// by kustomize (see kustomizeSplit).
+func digestImage(img, target string) image.Image {
+	name, tag := kustomizeSplit(img)
+	newName, newTag := kustomizeSplit(target)
+
+	result := image.Image{Name: regexp.QuoteMeta(name), NewName: newName}
+	if newTag != tag {
+		if strings.HasPrefix(newTag, "@") {
+			result.Digest = newTag[1:]
+		} else {
+			result.NewTag = strings.TrimPrefix(newTag, ":")
+		}
+	}
+	return result
+}
+
+// mirror returns the name of an image in the mirror of its registry. Mirrors map a registry, e.g. "quay.io", to the
+// registry and path prefix of its mirror, e.g. "mirror.corp/quay". Images without a registry are Docker Hub images,
+// official Docker Hub images are mirrored with their "library/" path. Unmirrored images are returned as is.
+func mirror(name string, mirrors map[string]string) string {
+	registry, path := dockerHub, name
+	if i := strings.Index(name, "/"); i >= 0 {
+		first := name[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			registry, path = first, name[i+1:]
+		}
+	}
+	if registry == dockerHub && !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+
+	m, ok := mirrors[registry]
+	if !ok {
+		return name
+	}
+	return strings.TrimSuffix(m, "/") + "/" + path
+}
+
+// images returns the kustomize image rewrites for all container images used by the templates. Instance overrides
+// are applied first, the image name is then rewritten to the mirror of its registry. Images pinned by a digest get a
+// rewrite of their own, see digestImage.
+func images(templates map[string]string, overrides []v1beta1.ImageOverride, mirrors map[string]string) ([]image.Image, error) {
+	if len(overrides) == 0 && len(mirrors) == 0 {
+		return nil, nil
+	}
+
+	var objs []runtime.Object
+	for name, t := range templates {
+		o, err := YamlToObject(t)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template %s: %w", name, err)
+		}
+		objs = append(objs, o...)
+	}
+	used, err := ContainerImages(objs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	var result []image.Image
+	for _, u := range used {
+		name, tag := splitImage(u)
+
+		img := image.Image{}
+		for _, o := range overrides {
+			if o.Name == name {
+				img = image.Image{NewName: o.NewName, NewTag: o.NewTag, Digest: o.Digest}
+				break
+			}
+		}
+
+		if strings.Contains(tag, "@") {
+			switch {
+			case img.Digest != "":
+				tag = "@" + img.Digest
+			case img.NewTag != "":
+				tag = ":" + img.NewTag
+			}
+			newName := name
+			if img.NewName != "" {
+				newName = img.NewName
+			}
+			if target := mirror(newName, mirrors) + tag; target != u {
+				result = append(result, digestImage(u, target))
+			}
+			continue
+		}
+
+		if names[name] {
+			continue
+		}
+		names[name] = true
+
+		newName := name
+		if img.NewName != "" {
+			newName = img.NewName
+		}
+		if mirrored := mirror(newName, mirrors); mirrored != name {
+			img.NewName = mirrored
+		}
+
+		if img == (image.Image{}) {
+			continue
+		}
+		// kustomize matches image names as regular expressions
+		img.Name = regexp.QuoteMeta(name)
+		result = append(result, img)
+	}
+	return result, nil
+}
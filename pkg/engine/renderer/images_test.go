@@ -0,0 +1,97 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/kustomize/pkg/image"
+)
+
+func TestContainerImages(t *testing.T) {
+	objs, err := YamlToObject(`apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  initContainers:
+  - name: init
+    image: busybox
+  containers:
+  - name: app
+    image: nginx:1.17
+  - name: other
+    image: busybox
+---
+apiVersion: example.com/v1
+kind: Custom
+metadata:
+  name: custom
+spec:
+  podTemplate:
+    spec:
+      containers:
+      - name: app
+        image: quay.io/org/app@sha256:abc
+`)
+	assert.NoError(t, err)
+
+	images, err := ContainerImages(objs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"busybox", "nginx:1.17", "quay.io/org/app@sha256:abc"}, images)
+}
+
+func TestMirror(t *testing.T) {
+	mirrors := map[string]string{
+		"docker.io":      "mirror.corp/dockerhub",
+		"localhost:5000": "mirror.corp/local",
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"nginx", "mirror.corp/dockerhub/library/nginx"},
+		{"bitnami/kafka", "mirror.corp/dockerhub/bitnami/kafka"},
+		{"docker.io/bitnami/kafka", "mirror.corp/dockerhub/bitnami/kafka"},
+		{"localhost:5000/app", "mirror.corp/local/app"},
+		{"quay.io/org/app", "quay.io/org/app"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, mirror(tt.name, mirrors), tt.name)
+	}
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{"nginx", "nginx", ""},
+		{"nginx:1.17", "nginx", ":1.17"},
+		{"localhost:5000/app", "localhost:5000/app", ""},
+		{"localhost:5000/app:1.0", "localhost:5000/app", ":1.0"},
+		{"quay.io/org/app@sha256:abc", "quay.io/org/app", "@sha256:abc"},
+		{"nginx:1.17@sha256:abc", "nginx", ":1.17@sha256:abc"},
+	}
+	for _, tt := range tests {
+		name, tag := splitImage(tt.image)
+		assert.Equal(t, tt.name, name, tt.image)
+		assert.Equal(t, tt.tag, tag, tt.image)
+	}
+}
+
+func TestDigestImage(t *testing.T) {
+	tests := []struct {
+		image  string
+		target string
+		want   image.Image
+	}{
+		{"quay.io/org/app@sha256:abc", "mirror.corp/org/app@sha256:abc", image.Image{Name: `quay\.io/org/app@sha256`, NewName: "mirror.corp/org/app@sha256"}},
+		{"nginx:1.17@sha256:abc", "mirror.corp/library/nginx:1.17@sha256:abc", image.Image{Name: `nginx:1\.17@sha256`, NewName: "mirror.corp/library/nginx:1.17@sha256"}},
+		{"nginx@sha256:abc", "nginx@sha256:def", image.Image{Name: `nginx@sha256`, NewName: "nginx@sha256", NewTag: "def"}},
+		{"nginx@sha256:abc", "nginx:1.17", image.Image{Name: `nginx@sha256`, NewName: "nginx", NewTag: "1.17"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, digestImage(tt.image, tt.target), tt.image)
+	}
+}
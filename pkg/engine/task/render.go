@@ -23,6 +23,10 @@ func render(resourceNames []string, ctx Context) (map[string]string, error) {
 
 	resources := map[string]string{}
 	engine := renderer.New()
+	engine.Partials = partials(ctx.Templates)
+	if ctx.Client != nil {
+		engine.FuncMap["lookup"] = renderer.Lookup(ctx.Client, ctx.Mapper, ctx.Meta.InstanceNamespace)
+	}
 
 	for _, rn := range resourceNames {
 		resource, ok := ctx.Templates[rn]
@@ -41,9 +45,66 @@ func render(resourceNames []string, ctx Context) (map[string]string, error) {
 	return resources, nil
 }
 
+// partials returns all partial templates (see renderer.IsPartial) which are then available to every rendered template.
+// Partials of embedded Helm charts are excluded, they use Helm functions and values and are only rendered by HelmTask.
+func partials(templates map[string]string) map[string]string {
+	partials := map[string]string{}
+	for name, t := range templates {
+		if renderer.IsPartial(name) && !IsChartFile(name) {
+			partials[name] = t
+		}
+	}
+	return partials
+}
+
 // kustomize method takes a slice of rendered templates, applies conventions using Enhancer and
 // returns a slice of k8s objects.
 func kustomize(rendered map[string]string, meta renderer.Metadata, enhancer renderer.Enhancer) ([]runtime.Object, error) {
 	enhanced, err := enhancer.Apply(rendered, meta)
 	return enhanced, err
 }
+
+// Render renders and kustomizes the resources of a task without touching the cluster, e.g. to preview them from the
+// CLI. For a PipeTask only the pipe pod is returned since the pipe artifacts are generated by running it. Resources
+// selected by a DeleteTask selector are not included and Dummy tasks have no resources.
+func Render(t Tasker, ctx Context) ([]runtime.Object, error) {
+	var rendered map[string]string
+	var err error
+
+	switch t := t.(type) {
+	case ApplyTask:
+		rendered, err = render(t.Resources, ctx)
+	case DeleteTask:
+		rendered, err = render(t.Resources, ctx)
+	case HelmTask:
+		rendered, err = t.renderChart(ctx)
+	case PipeTask:
+		rendered, err = renderPipePod(t, ctx)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return kustomize(rendered, ctx.Meta, ctx.Enhancer)
+}
+
+// renderPipePod renders the pod of a PipeTask, see steps 1-4 of PipeTask.Run
+func renderPipePod(pt PipeTask, ctx Context) (map[string]string, error) {
+	rendered, err := render([]string{pt.Pod}, ctx)
+	if err != nil {
+		return nil, err
+	}
+	usrPod, err := unmarshal(rendered[pt.Pod])
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(usrPod, pt.PipeFiles); err != nil {
+		return nil, err
+	}
+	podYaml, err := pipePod(usrPod, PipePodName(ctx.Meta))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"pipe-pod.yaml": podYaml}, nil
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/engine"
@@ -15,11 +19,21 @@ import (
 // Context is a engine.task execution context containing k8s client, templates parameters etc.
 type Context struct {
 	Client     client.Client
+	Mapper     meta.RESTMapper // Resolves the scope of kinds looked up by templates, see renderer.Lookup
 	Enhancer   renderer.Enhancer
 	Meta       renderer.Metadata
 	Templates  map[string]string // Raw templates
 	Parameters map[string]string // Instance and OperatorVersion parameters merged
 	Pipes      map[string]string // Pipe artifacts
+	Log        logr.Logger       // Carries the instance, plan, phase, step and task, discards messages if unset
+}
+
+// Logger returns the logger of the task, tasks should log through it instead of using Log directly
+func (c Context) Logger() logr.Logger {
+	if c.Log == nil {
+		return log.NullLogger{}
+	}
+	return c.Log
 }
 
 // Tasker is an interface that represents any runnable task for an operator. This method is treated
@@ -40,14 +54,16 @@ const (
 	DeleteTaskKind = "Delete"
 	DummyTaskKind  = "Dummy"
 	PipeTaskKind   = "Pipe"
+	HelmTaskKind   = "Helm"
 )
 
 var (
-	taskRenderingError      = "TaskRenderingError"
-	taskEnhancementError    = "TaskEnhancementError"
-	dummyTaskError          = "DummyTaskError"
-	resourceUnmarshalError  = "ResourceUnmarshalError"
-	resourceValidationError = "ResourceValidationError"
+	taskRenderingError        = "TaskRenderingError"
+	taskEnhancementError      = "TaskEnhancementError"
+	immutableFieldChangeError = "ImmutableFieldChangeError"
+	dummyTaskError            = "DummyTaskError"
+	resourceUnmarshalError    = "ResourceUnmarshalError"
+	resourceValidationError   = "ResourceValidationError"
 )
 
 // Build factory method takes an v1beta1.Task and returns a corresponding Tasker object
@@ -61,6 +77,8 @@ func Build(task *v1beta1.Task) (Tasker, error) {
 		return newDummy(task)
 	case PipeTaskKind:
 		return newPipe(task)
+	case HelmTaskKind:
+		return newHelm(task)
 	default:
 		return nil, fmt.Errorf("unknown task kind %s", task.Kind)
 	}
@@ -80,13 +98,34 @@ func newApply(task *v1beta1.Task) (Tasker, error) {
 
 func newDelete(task *v1beta1.Task) (Tasker, error) {
 	// validate DeleteTask
-	if len(task.Spec.ResourceTaskSpec.Resources) == 0 {
-		return nil, errors.New("task validation error: delete task has an empty resource list. if that's what you need, use a Dummy task instead")
+	if len(task.Spec.ResourceTaskSpec.Resources) == 0 && task.Spec.DeleteTaskSpec.Selector == nil {
+		return nil, errors.New("task validation error: delete task has an empty resource list and no selector. if that's what you need, use a Dummy task instead")
+	}
+
+	propagation := metav1.DeletionPropagation(task.Spec.DeleteTaskSpec.PropagationPolicy)
+	switch propagation {
+	case "", metav1.DeletePropagationForeground, metav1.DeletePropagationBackground, metav1.DeletePropagationOrphan:
+	default:
+		return nil, fmt.Errorf("task validation error: invalid propagation policy %s (must be Foreground, Background or Orphan)", propagation)
+	}
+
+	if selector := task.Spec.DeleteTaskSpec.Selector; selector != nil {
+		if len(selector.Kinds) == 0 {
+			return nil, errors.New("task validation error: delete task selector has an empty kinds list")
+		}
+		for _, k := range selector.Kinds {
+			if k.APIVersion == "" || k.Kind == "" {
+				return nil, fmt.Errorf("task validation error: delete task selector kind needs apiVersion and kind: %v", k)
+			}
+		}
 	}
 
 	return DeleteTask{
-		Name:      task.Name,
-		Resources: task.Spec.ResourceTaskSpec.Resources,
+		Name:              task.Name,
+		Resources:         task.Spec.ResourceTaskSpec.Resources,
+		PropagationPolicy: propagation,
+		Wait:              task.Spec.DeleteTaskSpec.Wait,
+		Selector:          task.Spec.DeleteTaskSpec.Selector,
 	}, nil
 }
 
@@ -99,6 +138,19 @@ func newDummy(task *v1beta1.Task) (Tasker, error) {
 	}, nil
 }
 
+func newHelm(task *v1beta1.Task) (Tasker, error) {
+	// validate HelmTask
+	if task.Spec.HelmTaskSpec.Chart == "" {
+		return nil, errors.New("task validation error: helm task has no chart")
+	}
+
+	return HelmTask{
+		Name:   task.Name,
+		Chart:  task.Spec.HelmTaskSpec.Chart,
+		Values: task.Spec.HelmTaskSpec.Values,
+	}, nil
+}
+
 func newPipe(task *v1beta1.Task) (Tasker, error) {
 	// validate PipeTask
 	if len(task.Spec.PipeTaskSpec.Pipe) == 0 {
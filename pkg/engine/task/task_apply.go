@@ -2,11 +2,18 @@ package task
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	apijson "k8s.io/apimachinery/pkg/util/json"
@@ -14,8 +21,32 @@ import (
 
 	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/engine/health"
+	"github.com/kudobuilder/kudo/pkg/metrics"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
+// ForcedReapplyInterval is the maximum time an unchanged object is left alone. Objects that were last applied longer
+// ago are patched even if their content hash matches, so that out-of-band changes are eventually reverted.
+var ForcedReapplyInterval = 30 * time.Minute
+
+// Recreate policies which can be set using the kudo.RecreatePolicyAnnotation on a template object. They define how
+// an Apply task handles a patch that is rejected by the API server because it changes an immutable field.
+const (
+	// RecreatePolicyRecreate deletes the existing object (including its dependents) and creates it anew
+	RecreatePolicyRecreate = "recreate"
+	// RecreatePolicyOrphanRecreate deletes the existing object orphaning its dependents (e.g. StatefulSet pods
+	// and PVCs) and creates it anew
+	RecreatePolicyOrphanRecreate = "orphan-recreate"
+	// RecreatePolicyFail fails the task with a fatal error. This is the default policy
+	RecreatePolicyFail = "fail"
+)
+
+// ApplyPolicyCreateOnly can be set using the kudo.ApplyPolicyAnnotation on a template object. Such objects are created
+// once and are never patched afterwards e.g. generated password Secrets or PVCs.
+const ApplyPolicyCreateOnly = "create-only"
+
+var errImmutableFieldChange = errors.New("immutable field change")
+
 // ApplyTask will apply a set of given resources to the cluster. See Run method for more details.
 type ApplyTask struct {
 	Name      string
@@ -38,29 +69,138 @@ func (at ApplyTask) Run(ctx Context) (bool, error) {
 		return false, fatalExecutionError(err, taskEnhancementError, ctx.Meta)
 	}
 
-	// 3. - Apply them using the client -
-	applied, err := apply(kustomized, ctx.Client)
+	// 3. - Apply them and check their health -
+	return applyAndCheckHealth(kustomized, ctx)
+}
+
+// applyAndCheckHealth applies the passed objects in order of their kinds using the context client and checks
+// the health of all of them.
+func applyAndCheckHealth(ro []runtime.Object, ctx Context) (bool, error) {
+	if err := trackUnownedResources(ro, ctx); err != nil {
+		return false, err
+	}
+
+	applied, err := apply(sortByKind(ro), ctx.Client, ctx.Logger())
 	if err != nil {
+		if errors.Is(err, errImmutableFieldChange) {
+			return false, fatalExecutionError(err, immutableFieldChangeError, ctx.Meta)
+		}
 		return false, err
 	}
 
-	// 4. - Check health for all resources -
-	err = isHealthy(applied)
+	err = isHealthy(applied, ctx.Logger())
 	if err != nil {
 		// so far we do not distinguish between unhealthy resources and other errors that might occur during a health check
 		// an error during a health check is not treated task execution error
-		log.Printf("TaskExecution: %v", err)
+		ctx.Logger().Info("task is not healthy yet", "reason", err.Error())
 		return false, nil
 	}
 	return true, nil
 }
 
+// trackUnownedResources records the kinds of the resources which the instance can not own, labeled by the enhancer
+// with the kudo.InstanceNamespaceLabel, on the owning instance and persists them together with the resources finalizer
+// before the resources are applied. Persisting them only with the instance status would leak the resources if the
+// status update fails and the instance is deleted in the meantime.
+func trackUnownedResources(ro []runtime.Object, ctx Context) error {
+	instance, ok := ctx.Meta.ResourcesOwner.(*v1beta1.Instance)
+	if !ok {
+		return nil
+	}
+	tracked := instance.DeepCopy()
+	changed := false
+	for _, r := range ro {
+		if _, unowned := r.(metav1.Object).GetLabels()[kudo.InstanceNamespaceLabel]; unowned && tracked.TrackUnownedResources(r.GetObjectKind().GroupVersionKind()) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	// only the finalizers and the annotation are patched, all other changes of the instance are persisted by the
+	// instance controller. The resource version makes the patch fail if the instance was changed in the meantime.
+	base := &v1beta1.Instance{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	patched := base.DeepCopy()
+	patched.ResourceVersion = instance.ResourceVersion
+	patched.Finalizers = tracked.Finalizers
+	patched.Annotations = map[string]string{kudo.UnownedResourcesAnnotation: tracked.Annotations[kudo.UnownedResourcesAnnotation]}
+	if err := ctx.Client.Patch(context.TODO(), patched, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to record unowned resources of instance %s/%s: %w", instance.Namespace, instance.Name, err)
+	}
+	instance.Finalizers = tracked.Finalizers
+	instance.Annotations = tracked.Annotations
+	instance.ResourceVersion = patched.ResourceVersion
+	return nil
+}
+
+// kindOrder defines the order in which objects of an Apply task are applied. Objects of kinds not in this list are
+// applied last, keeping their relative order. This makes sure that e.g. a Namespace and a ServiceAccount exist before
+// a Deployment using them is created.
+var kindOrder = []struct {
+	name  string
+	kinds []string
+}{
+	{name: "namespaces", kinds: []string{"Namespace"}},
+	{name: "crds", kinds: []string{"CustomResourceDefinition"}},
+	{name: "rbac", kinds: []string{"ServiceAccount", "ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding"}},
+	{name: "config", kinds: []string{"ConfigMap", "Secret"}},
+}
+
+// defaultKindGroup is the group of all kinds not listed in the kindOrder
+const defaultKindGroup = "resources"
+
+// KindGroup returns the rank and the name of the group of the passed kind in the apply order (see kindOrder).
+// Kinds which are not part of any group are ranked last, in the "resources" group.
+func KindGroup(kind string) (int, string) {
+	for i, group := range kindOrder {
+		for _, k := range group.kinds {
+			if k == kind {
+				return i, group.name
+			}
+		}
+	}
+	return len(kindOrder), defaultKindGroup
+}
+
+// kindRank returns the position of the object kind group in the kindOrder
+func kindRank(obj runtime.Object) int {
+	rank, _ := KindGroup(obj.GetObjectKind().GroupVersionKind().Kind)
+	return rank
+}
+
+// sortByKind returns the passed objects stably sorted by their kind according to the kindOrder
+func sortByKind(ro []runtime.Object) []runtime.Object {
+	sorted := make([]runtime.Object, len(ro))
+	copy(sorted, ro)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return kindRank(sorted[i]) < kindRank(sorted[j])
+	})
+	return sorted
+}
+
+// isCRD returns true if the passed object is a CustomResourceDefinition, typed or unstructured
+func isCRD(obj runtime.Object) bool {
+	return obj.GetObjectKind().GroupVersionKind().GroupKind() == health.CRDGroupKind
+}
+
 // apply method takes a slice of k8s object and applies them using passed client. If an object
-// doesn't exist it will be created. An already existing object will be patched.
-func apply(ro []runtime.Object, c client.Client) ([]runtime.Object, error) {
+// doesn't exist it will be created. An already existing object will be patched unless it is up to date.
+// Applying stops after a CRD which is not established yet: custom resources following it can not be created
+// before that. The task is then not healthy and the remaining objects are applied during one of the next runs.
+// Note that the manager's dynamic RESTMapper reloads itself once it encounters the newly established kind.
+func apply(ro []runtime.Object, c client.Client, log logr.Logger) ([]runtime.Object, error) {
 	applied := make([]runtime.Object, 0)
 
 	for _, r := range ro {
+		if len(applied) > 0 {
+			if last := applied[len(applied)-1]; isCRD(last) && health.IsHealthy(last, log) != nil {
+				key, _ := client.ObjectKeyFromObject(last)
+				log.Info("waiting for CRD to be established before applying remaining objects", "crd", key.Name)
+				break
+			}
+		}
+
 		key, _ := client.ObjectKeyFromObject(r)
 		existing := r.DeepCopyObject()
 
@@ -68,6 +208,7 @@ func apply(ro []runtime.Object, c client.Client) ([]runtime.Object, error) {
 
 		switch {
 		case apierrors.IsNotFound(err): // create resource if it doesn't exist
+			setLastApplied(r, time.Now())
 			err = c.Create(context.TODO(), r)
 			// c.Create always overrides the input, in this case, the object that had previously set GVK loses it (at least for integration tests)
 			// and this was causing problems in health module
@@ -78,14 +219,26 @@ func apply(ro []runtime.Object, c client.Client) ([]runtime.Object, error) {
 			if err != nil {
 				return nil, err
 			}
+			metrics.ApplyObjects.WithLabelValues(metrics.ApplyActionCreated).Inc()
 			applied = append(applied, r)
 		case err != nil: // raise any error other than StatusReasonNotFound
 			return nil, err
+		case isDeleting(existing): // wait for the resource to be gone before it can be re-created
+			return nil, fmt.Errorf("object %s/%s is being deleted, waiting for it to be gone", key.Namespace, key.Name)
+		case isCreateOnly(r) || isUpToDate(r, existing, time.Now()): // skip patching create-only or unchanged resource
+			existing.GetObjectKind().SetGroupVersionKind(r.GetObjectKind().GroupVersionKind())
+			metrics.ApplyObjects.WithLabelValues(metrics.ApplyActionSkipped).Inc()
+			applied = append(applied, existing)
 		default: // update existing resource
+			setLastApplied(r, time.Now())
 			err := patch(r, c)
+			if isImmutableFieldError(err) {
+				return nil, recreate(r, c, err, log)
+			}
 			if err != nil {
 				return nil, err
 			}
+			metrics.ApplyObjects.WithLabelValues(metrics.ApplyActionPatched).Inc()
 			applied = append(applied, r)
 		}
 	}
@@ -93,16 +246,116 @@ func apply(ro []runtime.Object, c client.Client) ([]runtime.Object, error) {
 	return applied, nil
 }
 
+// isUpToDate returns true if the rendered object doesn't need to be patched. Comparing the spec of the rendered object
+// with the live one is not easy: kubernetes native objects might have extra fields set by some kubernetes component.
+// Instead, we compare the content hash stamped by the enhancer during rendering with the one of the live object.
+// Objects without a content hash or those which were last applied more than ForcedReapplyInterval ago are never
+// considered up to date.
+func isUpToDate(rendered runtime.Object, live runtime.Object, now time.Time) bool {
+	renderedMeta, err := meta.Accessor(rendered)
+	if err != nil {
+		return false
+	}
+	liveMeta, err := meta.Accessor(live)
+	if err != nil {
+		return false
+	}
+
+	hash := renderedMeta.GetAnnotations()[kudo.ContentHashAnnotation]
+	if hash == "" || hash != liveMeta.GetAnnotations()[kudo.ContentHashAnnotation] {
+		return false
+	}
+
+	lastApplied, err := time.Parse(time.RFC3339, liveMeta.GetAnnotations()[kudo.LastAppliedAnnotation])
+	if err != nil {
+		return false
+	}
+	return now.Sub(lastApplied) < ForcedReapplyInterval
+}
+
+// setLastApplied stamps the passed time in the kudo.LastAppliedAnnotation of the object
+func setLastApplied(obj runtime.Object, now time.Time) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	annotations := objMeta.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[kudo.LastAppliedAnnotation] = now.UTC().Format(time.RFC3339)
+	objMeta.SetAnnotations(annotations)
+}
+
+// isCreateOnly returns true if the object has the ApplyPolicyCreateOnly apply policy
+func isCreateOnly(obj runtime.Object) bool {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return objMeta.GetAnnotations()[kudo.ApplyPolicyAnnotation] == ApplyPolicyCreateOnly
+}
+
+// ignoredFields returns the list of field paths from the kudo.IgnoreFieldsAnnotation. The annotation value is a comma
+// separated list of dot separated field paths e.g. "spec.replicas,spec.template.metadata.annotations". Keys containing
+// dots can be addressed with JSON pointer paths e.g. "/metadata/annotations/kudo.dev~1foo" (see fieldPath)
+func ignoredFields(obj runtime.Object) []string {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil
+	}
+
+	fields := make([]string, 0)
+	for _, f := range strings.Split(objMeta.GetAnnotations()[kudo.IgnoreFieldsAnnotation], ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// patchContent marshals the passed object into the patch body. Ignored fields (see ignoredFields) are stripped from
+// the patch so that their live values, which are owned by users or e.g. autoscalers, are left untouched.
+func patchContent(obj runtime.Object) ([]byte, error) {
+	fields := ignoredFields(obj)
+	if len(fields) == 0 {
+		return apijson.Marshal(obj)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.DeepCopyObject())
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		unstructured.RemoveNestedField(content, fieldPath(f)...)
+	}
+	return apijson.Marshal(content)
+}
+
+// fieldPath splits an ignored field path into its keys. Paths starting with a '/' are JSON pointers (RFC 6901) where
+// '~1' and '~0' are unescaped to '/' and '~', all other paths are split at dots.
+func fieldPath(f string) []string {
+	if !strings.HasPrefix(f, "/") {
+		return strings.Split(f, ".")
+	}
+	keys := strings.Split(f[1:], "/")
+	for i, k := range keys {
+		keys[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(k)
+	}
+	return keys
+}
+
 // patch calls update method on kubernetes client to make sure the current resource reflects what is on server
 //
-// an obvious optimization here would be to not patch when objects are the same, however that is not easy
-// kubernetes native objects might be a problem because we cannot just compare the spec as the spec might have extra fields
-// and those extra fields are set by some kubernetes component
-// because of that for now we just try to apply the patch every time
+// unchanged objects are filtered out beforehand (see isUpToDate) so we only get here when the rendered content changed,
+// the live object has no content hash yet or a periodic re-apply is due
 // it mutates the object passed in to be consistent with the kubernetes client behavior
 func patch(newObj runtime.Object, c client.Client) error {
-	newObjJSON, _ := apijson.Marshal(newObj)
 	key, _ := client.ObjectKeyFromObject(newObj)
+	newObjJSON, err := patchContent(newObj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch for object %s/%s: %w", key.Namespace, key.Name, err)
+	}
 	_, isUnstructured := newObj.(runtime.Unstructured)
 	_, isCRD := newObj.(*apiextv1beta1.CustomResourceDefinition)
 
@@ -122,6 +375,68 @@ func patch(newObj runtime.Object, c client.Client) error {
 	return nil
 }
 
+// isImmutableFieldError returns true if the API server rejected a patch because it tried to change an immutable field
+// e.g. the selector of a Deployment, the volumeClaimTemplates of a StatefulSet or the pod template of a Job.
+func isImmutableFieldError(err error) bool {
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) || !apierrors.IsInvalid(statusErr) {
+		return false
+	}
+	if statusErr.ErrStatus.Details == nil {
+		return false
+	}
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		// StatefulSets report changes to fields other than 'replicas', 'template', and 'updateStrategy' as forbidden
+		if strings.Contains(cause.Message, "field is immutable") || strings.Contains(cause.Message, "Forbidden: updates to") {
+			return true
+		}
+	}
+	return false
+}
+
+// recreate handles an immutable field change according to the recreate policy of the object. For the recreate
+// policies the existing object is deleted and a transient error is returned: the object is created anew by one of the
+// next task executions once the old one is gone. Without a policy, an errImmutableFieldChange is returned.
+func recreate(obj runtime.Object, c client.Client, cause error, log logr.Logger) error {
+	key, _ := client.ObjectKeyFromObject(obj)
+
+	var propagation metav1.DeletionPropagation
+	switch policy := recreatePolicy(obj); policy {
+	case RecreatePolicyRecreate:
+		propagation = metav1.DeletePropagationForeground
+	case RecreatePolicyOrphanRecreate:
+		propagation = metav1.DeletePropagationOrphan
+	case RecreatePolicyFail, "":
+		return fmt.Errorf("%w of object %s/%s (set %s annotation to %s or %s to allow recreating it): %v",
+			errImmutableFieldChange, key.Namespace, key.Name, kudo.RecreatePolicyAnnotation, RecreatePolicyRecreate, RecreatePolicyOrphanRecreate, cause)
+	default:
+		return fmt.Errorf("%w of object %s/%s with an unknown recreate policy %q: %v", errImmutableFieldChange, key.Namespace, key.Name, policy, cause)
+	}
+
+	log.Info("recreating object because of an immutable field change", "objectNamespace", key.Namespace, "object", key.Name, "propagation", propagation)
+	err := c.Delete(context.TODO(), obj, client.PropagationPolicy(propagation))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete object %s/%s before recreating it: %w", key.Namespace, key.Name, err)
+	}
+	return fmt.Errorf("object %s/%s is being recreated because of an immutable field change", key.Namespace, key.Name)
+}
+
+func recreatePolicy(obj runtime.Object) string {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return objMeta.GetAnnotations()[kudo.RecreatePolicyAnnotation]
+}
+
+func isDeleting(obj runtime.Object) bool {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return objMeta.GetDeletionTimestamp() != nil
+}
+
 func isKudoType(object runtime.Object) bool {
 	_, isOperator := object.(*v1beta1.OperatorVersion)
 	_, isOperatorVersion := object.(*v1beta1.Operator)
@@ -129,9 +444,9 @@ func isKudoType(object runtime.Object) bool {
 	return isOperator || isOperatorVersion || isInstance
 }
 
-func isHealthy(ro []runtime.Object) error {
+func isHealthy(ro []runtime.Object, log logr.Logger) error {
 	for _, r := range ro {
-		err := health.IsHealthy(r)
+		err := health.IsHealthy(r, log)
 		if err != nil {
 			key, _ := client.ObjectKeyFromObject(r)
 			return fmt.Errorf("object %s/%s is NOT healthy: %w", key.Namespace, key.Name, err)
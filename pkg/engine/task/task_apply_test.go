@@ -1,21 +1,31 @@
 package task
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/yaml"
 
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/engine"
 	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
 func TestApplyTask_Run(t *testing.T) {
@@ -132,6 +142,211 @@ func TestApplyTask_Run(t *testing.T) {
 	}
 }
 
+func TestApply_SkipsUpToDateObjects(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		liveHash    string
+		lastApplied time.Time
+		patched     bool
+	}{
+		{name: "skips the patch when the content hash matches", liveHash: "hash", lastApplied: now, patched: false},
+		{name: "patches when the content hash differs", liveHash: "other", lastApplied: now, patched: true},
+		{name: "patches when a forced re-apply is due", liveHash: "hash", lastApplied: now.Add(-2 * ForcedReapplyInterval), patched: true},
+	}
+
+	for _, tt := range tests {
+		live := pod("pod1", "default")
+		live.Annotations = map[string]string{
+			kudo.ContentHashAnnotation: tt.liveHash,
+			kudo.LastAppliedAnnotation: tt.lastApplied.UTC().Format(time.RFC3339),
+		}
+		c := fake.NewFakeClientWithScheme(scheme.Scheme, live)
+
+		rendered := pod("pod1", "default")
+		rendered.Labels = map[string]string{"foo": "bar"}
+		rendered.Annotations = map[string]string{kudo.ContentHashAnnotation: "hash"}
+
+		_, err := apply([]runtime.Object{rendered}, c, log.NullLogger{})
+		assert.NoError(t, err, tt.name)
+
+		got := &corev1.Pod{}
+		err = c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "pod1"}, got)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.patched, got.Labels["foo"] == "bar", tt.name)
+	}
+}
+
+func TestApply_Policies(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantLabels  map[string]string
+	}{
+		{
+			name:        "patches an object without policies",
+			annotations: map[string]string{},
+			wantLabels:  map[string]string{"foo": "rendered", "bar": "rendered"},
+		},
+		{
+			name:        "does not patch a create-only object",
+			annotations: map[string]string{kudo.ApplyPolicyAnnotation: ApplyPolicyCreateOnly},
+			wantLabels:  map[string]string{"foo": "live"},
+		},
+		{
+			name:        "strips ignored fields from the patch",
+			annotations: map[string]string{kudo.IgnoreFieldsAnnotation: "metadata.labels.foo, spec.nodeName"},
+			wantLabels:  map[string]string{"foo": "live", "bar": "rendered"},
+		},
+	}
+
+	for _, tt := range tests {
+		live := pod("pod1", "default")
+		live.Labels = map[string]string{"foo": "live"}
+		c := fake.NewFakeClientWithScheme(scheme.Scheme, live)
+
+		rendered := pod("pod1", "default")
+		rendered.Labels = map[string]string{"foo": "rendered", "bar": "rendered"}
+		rendered.Annotations = tt.annotations
+
+		_, err := apply([]runtime.Object{rendered}, c, log.NullLogger{})
+		assert.NoError(t, err, tt.name)
+
+		got := &corev1.Pod{}
+		err = c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "pod1"}, got)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.wantLabels, got.Labels, tt.name)
+	}
+}
+
+func TestApply_IgnoredDottedKeys(t *testing.T) {
+	live := pod("pod1", "default")
+	live.Labels = map[string]string{"app.kubernetes.io/name": "live"}
+	live.Annotations = map[string]string{"kudo.dev/foo": "live"}
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, live)
+
+	rendered := pod("pod1", "default")
+	rendered.Labels = map[string]string{"app.kubernetes.io/name": "rendered", "bar": "rendered"}
+	rendered.Annotations = map[string]string{
+		"kudo.dev/foo":              "rendered",
+		kudo.IgnoreFieldsAnnotation: "/metadata/labels/app.kubernetes.io~1name, /metadata/annotations/kudo.dev~1foo",
+	}
+
+	_, err := apply([]runtime.Object{rendered}, c, log.NullLogger{})
+	assert.NoError(t, err)
+
+	got := &corev1.Pod{}
+	assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "pod1"}, got))
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "live", "bar": "rendered"}, got.Labels)
+	assert.Equal(t, "live", got.Annotations["kudo.dev/foo"])
+}
+
+func TestTrackUnownedResources(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, scheme.AddToScheme(s))
+	assert.NoError(t, v1beta1.AddToScheme(s))
+
+	instance := &v1beta1.Instance{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	c := fake.NewFakeClientWithScheme(s, instance)
+	instance = &v1beta1.Instance{}
+	assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test"}, instance))
+	// the instance controller persists other changes of the instance later
+	instance.Spec.Parameters = map[string]string{"foo": "bar"}
+
+	owned := pod("pod1", "default")
+	unowned := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "other", Labels: map[string]string{kudo.InstanceNamespaceLabel: "default"}},
+	}
+	ctx := Context{Client: c, Meta: renderer.Metadata{Metadata: engine.Metadata{ResourcesOwner: instance}}}
+	assert.NoError(t, trackUnownedResources([]runtime.Object{owned, unowned}, ctx))
+
+	got := &v1beta1.Instance{}
+	assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test"}, got))
+	assert.True(t, got.HasResourcesFinalizer())
+	assert.Equal(t, "v1/ConfigMap", got.Annotations[kudo.UnownedResourcesAnnotation])
+	assert.Empty(t, got.Spec.Parameters)
+	assert.Equal(t, got.ResourceVersion, instance.ResourceVersion)
+
+	// the instance is only patched once
+	assert.NoError(t, trackUnownedResources([]runtime.Object{unowned}, ctx))
+	assert.Equal(t, got.ResourceVersion, instance.ResourceVersion)
+
+	// the instance is not changed if the patch fails
+	missing := &v1beta1.Instance{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"}}
+	ctx.Meta.ResourcesOwner = missing
+	assert.Error(t, trackUnownedResources([]runtime.Object{unowned}, ctx))
+	assert.False(t, missing.HasResourcesFinalizer())
+	assert.Empty(t, missing.Annotations)
+}
+
+func TestFieldPath(t *testing.T) {
+	assert.Equal(t, []string{"spec", "replicas"}, fieldPath("spec.replicas"))
+	assert.Equal(t, []string{"metadata", "annotations", "kudo.dev/foo"}, fieldPath("/metadata/annotations/kudo.dev~1foo"))
+	assert.Equal(t, []string{"metadata", "labels", "a~1b"}, fieldPath("/metadata/labels/a~01b"))
+}
+
+func TestSortByKind(t *testing.T) {
+	cm := &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"}}
+	sa := &corev1.ServiceAccount{TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"}}
+	ns := &corev1.Namespace{TypeMeta: metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"}}
+	p1 := pod("pod1", "default")
+	p2 := pod("pod2", "default")
+	j := job("job1", "default")
+	c := crd("mycrds.mycrd.k8s.io")
+
+	got := sortByKind([]runtime.Object{p1, cm, j, c, sa, p2, ns})
+	assert.Equal(t, []runtime.Object{ns, c, sa, cm, p1, j, p2}, got)
+}
+
+func TestApply_WaitsForCRD(t *testing.T) {
+	c := fake.NewFakeClientWithScheme(scheme.Scheme)
+
+	applied, err := apply(sortByKind([]runtime.Object{pod("pod1", "default"), crd("mycrds.mycrd.k8s.io")}), c, log.NullLogger{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(applied))
+	assert.True(t, isCRD(applied[0]))
+	assert.Error(t, isHealthy(applied, log.NullLogger{}))
+
+	err = c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "pod1"}, &corev1.Pod{})
+	assert.True(t, apierrors.IsNotFound(err), "pod should not be applied before the CRD is established")
+}
+
+func TestRecreate(t *testing.T) {
+	immutableErr := apierrors.NewInvalid(schema.GroupKind{Group: "batch", Kind: "Job"}, "job1", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "template"), "", "field is immutable"),
+	})
+	assert.True(t, isImmutableFieldError(fmt.Errorf("wrapped: %w", immutableErr)))
+	assert.False(t, isImmutableFieldError(apierrors.NewBadRequest("bad request")))
+
+	tests := []struct {
+		name    string
+		policy  string
+		deleted bool
+		fatal   bool
+	}{
+		{name: "fails without a recreate policy", policy: "", deleted: false, fatal: true},
+		{name: "fails with the fail policy", policy: RecreatePolicyFail, deleted: false, fatal: true},
+		{name: "fails with an unknown policy", policy: "foo", deleted: false, fatal: true},
+		{name: "deletes the object with the recreate policy", policy: RecreatePolicyRecreate, deleted: true, fatal: false},
+		{name: "deletes the object with the orphan-recreate policy", policy: RecreatePolicyOrphanRecreate, deleted: true, fatal: false},
+	}
+
+	for _, tt := range tests {
+		obj := job("job1", "default")
+		obj.Annotations = map[string]string{kudo.RecreatePolicyAnnotation: tt.policy}
+		c := fake.NewFakeClientWithScheme(scheme.Scheme, obj)
+
+		err := recreate(obj, c, immutableErr, log.NullLogger{})
+		assert.Error(t, err, tt.name)
+		assert.Equal(t, tt.fatal, errors.Is(err, errImmutableFieldChange), tt.name)
+
+		err = c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "job1"}, &batchv1.Job{})
+		assert.Equal(t, tt.deleted, apierrors.IsNotFound(err), tt.name)
+	}
+}
+
 func pod(name string, namespace string) *corev1.Pod { //nolint:unparam
 	pod := &corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
@@ -165,6 +380,14 @@ func job(name string, namespace string) *batchv1.Job {
 	return job
 }
 
+func crd(name string) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1beta1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName(name)
+	return crd
+}
+
 func resourceAsString(resource metav1.Object) string {
 	bytes, _ := yaml.Marshal(resource)
 	return string(bytes)
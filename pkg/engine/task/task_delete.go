@@ -1,21 +1,34 @@
 package task
 
 import (
+	"fmt"
+
+	"github.com/go-logr/logr"
 	"golang.org/x/net/context"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
 // DeleteTask will delete a set of given resources from the cluster. See Run method for more details.
 type DeleteTask struct {
-	Name      string
-	Resources []string
+	Name              string
+	Resources         []string
+	PropagationPolicy metav1.DeletionPropagation
+	Wait              bool
+	Selector          *v1beta1.DeleteSelector
 }
 
 // Run method for the DeleteTask. Given the task context, it renders the templates using context parameters
-// creates runtime objects and kustomizes them, and finally removes them using the controller client.
+// creates runtime objects and kustomizes them, and finally removes them using the controller client. Instance
+// resources matching an optional selector are removed too. If the task should wait for the deletion, it is
+// only done once all the removed objects are gone.
 func (dt DeleteTask) Run(ctx Context) (bool, error) {
 	// 1. - Render task templates -
 	rendered, err := render(dt.Resources, ctx)
@@ -29,19 +42,35 @@ func (dt DeleteTask) Run(ctx Context) (bool, error) {
 		return false, fatalExecutionError(err, taskEnhancementError, ctx.Meta)
 	}
 
-	// 3. - Delete them using the client -
-	err = delete(kustomized, ctx.Client)
+	// 3. - Select additional instance resources -
+	if dt.Selector != nil {
+		selected, err := selectInstanceResources(dt.Selector, ctx)
+		if err != nil {
+			return false, err
+		}
+		kustomized = append(kustomized, selected...)
+	}
+
+	// 4. - Delete them using the client -
+	err = delete(kustomized, dt.PropagationPolicy, ctx.Client)
 	if err != nil {
 		return false, err
 	}
 
-	// 4. - Check health: always true for Delete task -
+	// 5. - Check health: all objects are gone if we have to wait, otherwise always true -
+	if dt.Wait {
+		return isDeleted(kustomized, ctx.Client, ctx.Logger())
+	}
 	return true, nil
 }
 
-func delete(ro []runtime.Object, c client.Client) error {
+func delete(ro []runtime.Object, propagation metav1.DeletionPropagation, c client.Client) error {
+	if propagation == "" {
+		propagation = metav1.DeletePropagationForeground
+	}
+
 	for _, r := range ro {
-		err := c.Delete(context.TODO(), r, client.PropagationPolicy(metav1.DeletePropagationForeground))
+		err := c.Delete(context.TODO(), r, client.PropagationPolicy(propagation))
 		if !apierrors.IsNotFound(err) && err != nil {
 			return err
 		}
@@ -49,3 +78,54 @@ func delete(ro []runtime.Object, c client.Client) error {
 
 	return nil
 }
+
+// isDeleted returns true if none of the passed objects exists anymore. Objects might linger after they were deleted
+// e.g. waiting for their finalizers or, in case of PVCs, for the pods using them to be gone.
+func isDeleted(ro []runtime.Object, c client.Client, log logr.Logger) (bool, error) {
+	for _, r := range ro {
+		key, _ := client.ObjectKeyFromObject(r)
+		err := c.Get(context.TODO(), key, r.DeepCopyObject())
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return false, err
+		default:
+			log.Info("waiting for object to be deleted", "kind", r.GetObjectKind().GroupVersionKind().Kind, "objectNamespace", key.Namespace, "object", key.Name)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// selectInstanceResources lists resources of the selector kinds in the instance namespace. Only resources that belong
+// to the instance (have KUDO instance labels) and match the selector labels are returned.
+func selectInstanceResources(selector *v1beta1.DeleteSelector, ctx Context) ([]runtime.Object, error) {
+	labels := client.MatchingLabels{}
+	for k, v := range selector.MatchLabels {
+		labels[k] = v
+	}
+	labels[kudo.HeritageLabel] = "kudo"
+	labels[kudo.OperatorLabel] = ctx.Meta.OperatorName
+	labels[kudo.InstanceLabel] = ctx.Meta.InstanceName
+
+	selected := make([]runtime.Object, 0)
+	for _, kind := range selector.Kinds {
+		gv, err := schema.ParseGroupVersion(kind.APIVersion)
+		if err != nil {
+			return nil, fatalExecutionError(fmt.Errorf("invalid selector apiVersion %s: %v", kind.APIVersion, err), resourceValidationError, ctx.Meta)
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gv.WithKind(kind.Kind + "List"))
+		err = ctx.Client.List(context.TODO(), list, client.InNamespace(ctx.Meta.InstanceNamespace), labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s resources: %w", kind.Kind, err)
+		}
+
+		for i := range list.Items {
+			selected = append(selected, &list.Items[i])
+		}
+	}
+	return selected, nil
+}
@@ -1,16 +1,23 @@
 package task
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/engine"
 	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
 func TestDeleteTask_Run(t *testing.T) {
@@ -111,3 +118,57 @@ func TestDeleteTask_Run(t *testing.T) {
 		}
 	}
 }
+
+func TestDeleteTask_RunWithSelector(t *testing.T) {
+	meta := renderer.Metadata{
+		Metadata: engine.Metadata{
+			InstanceName:      "test",
+			InstanceNamespace: "default",
+			OperatorName:      "first-operator",
+		},
+	}
+
+	labeled := func(name string, namespace string, labels map[string]string) *corev1.Pod {
+		p := pod(name, namespace)
+		p.Labels = labels
+		return p
+	}
+	instanceLabels := map[string]string{
+		kudo.HeritageLabel: "kudo",
+		kudo.OperatorLabel: "first-operator",
+		kudo.InstanceLabel: "test",
+		"app":              "zk",
+	}
+	otherInstanceLabels := map[string]string{
+		kudo.HeritageLabel: "kudo",
+		kudo.OperatorLabel: "first-operator",
+		kudo.InstanceLabel: "other",
+		"app":              "zk",
+	}
+
+	c := fake.NewFakeClientWithScheme(scheme.Scheme,
+		labeled("selected", "default", instanceLabels),
+		labeled("other-instance", "default", otherInstanceLabels),
+		labeled("other-namespace", "other", instanceLabels),
+	)
+
+	task := DeleteTask{
+		Name: "task",
+		Wait: true,
+		Selector: &v1beta1.DeleteSelector{
+			Kinds:       []metav1.TypeMeta{{APIVersion: "v1", Kind: "Pod"}},
+			MatchLabels: map[string]string{"app": "zk"},
+		},
+	}
+
+	done, err := task.Run(Context{Client: c, Enhancer: &testEnhancer{}, Meta: meta})
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	err = c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "selected"}, &corev1.Pod{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "other-instance"}, &corev1.Pod{})
+	assert.NoError(t, err)
+	err = c.Get(context.TODO(), client.ObjectKey{Namespace: "other", Name: "other-namespace"}, &corev1.Pod{})
+	assert.NoError(t, err)
+}
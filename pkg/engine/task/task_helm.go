@@ -0,0 +1,146 @@
+package task
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	helmengine "helm.sh/helm/v3/pkg/engine"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ChartsDir is the directory in the operator package templates which contains embedded Helm charts. Chart files are
+// part of the operator version templates, prefixed with the chart directory e.g. "charts/redis/Chart.yaml".
+const ChartsDir = "charts"
+
+// helmHookAnnotation marks chart hooks. Hooks are not supported and are skipped
+const helmHookAnnotation = "helm.sh/hook"
+
+var manifestSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// HelmTask renders an embedded Helm chart and applies the rendered manifests. See Run method for more details.
+type HelmTask struct {
+	Name   string
+	Chart  string
+	Values string
+}
+
+// IsChartFile returns true if the template with the given name is part of an embedded Helm chart
+func IsChartFile(name string) bool {
+	return strings.HasPrefix(name, ChartsDir+"/")
+}
+
+// ChartFiles returns the files of the given embedded chart with names relative to the chart directory
+func ChartFiles(templates map[string]string, chart string) map[string]string {
+	prefix := path.Join(ChartsDir, chart) + "/"
+	files := map[string]string{}
+	for name, content := range templates {
+		if strings.HasPrefix(name, prefix) {
+			files[strings.TrimPrefix(name, prefix)] = content
+		}
+	}
+	return files
+}
+
+// Run method for the HelmTask. Given the task context, it renders the values template using context parameters
+// and uses the result as values to render the chart offline with the Helm library. The resulting manifests
+// are then kustomized and applied, and checked for health, exactly like the resources of an ApplyTask.
+// The instance name and namespace are used as the Helm release name and namespace. Chart hooks are skipped.
+func (ht HelmTask) Run(ctx Context) (bool, error) {
+	// 1. - Render chart values and the chart -
+	manifests, err := ht.renderChart(ctx)
+	if err != nil {
+		return false, fatalExecutionError(err, taskRenderingError, ctx.Meta)
+	}
+
+	// 2. - Kustomize them with metadata -
+	kustomized, err := kustomize(manifests, ctx.Meta, ctx.Enhancer)
+	if err != nil {
+		return false, fatalExecutionError(err, taskEnhancementError, ctx.Meta)
+	}
+
+	// 3. - Apply them and check their health -
+	return applyAndCheckHealth(kustomized, ctx)
+}
+
+// renderChart returns the manifests of the rendered chart. Multi-document templates are split so that every returned
+// manifest contains exactly one object.
+func (ht HelmTask) renderChart(ctx Context) (map[string]string, error) {
+	values := map[string]interface{}{}
+	if ht.Values != "" {
+		rendered, err := render([]string{ht.Values}, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal([]byte(rendered[ht.Values]), &values); err != nil {
+			return nil, fmt.Errorf("failed to parse chart values %s: %w", ht.Values, err)
+		}
+	}
+
+	files := ChartFiles(ctx.Templates, ht.Chart)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("chart %s not found in %s", ht.Chart, ChartsDir)
+	}
+	bufferedFiles := make([]*loader.BufferedFile, 0, len(files))
+	for name, content := range files {
+		bufferedFiles = append(bufferedFiles, &loader.BufferedFile{Name: name, Data: []byte(content)})
+	}
+	chart, err := loader.LoadFiles(bufferedFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s: %w", ht.Chart, err)
+	}
+
+	options := chartutil.ReleaseOptions{Name: ctx.Meta.InstanceName, Namespace: ctx.Meta.InstanceNamespace, IsInstall: true}
+	renderValues, err := chartutil.ToRenderValues(chart, values, options, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose values of chart %s: %w", ht.Chart, err)
+	}
+	rendered, err := helmengine.Render(chart, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart %s: %w", ht.Chart, err)
+	}
+
+	return splitManifests(rendered, ctx.Logger())
+}
+
+// splitManifests splits the rendered chart templates into single object manifests. Helpers, notes, empty documents
+// and hooks are dropped.
+func splitManifests(rendered map[string]string, log logr.Logger) (map[string]string, error) {
+	names := make([]string, 0, len(rendered))
+	for name := range rendered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifests := map[string]string{}
+	for _, name := range names {
+		if path.Ext(name) != ".yaml" && path.Ext(name) != ".yml" {
+			continue // e.g. NOTES.txt or _helpers.tpl
+		}
+
+		for i, doc := range manifestSeparator.Split(rendered[name], -1) {
+			var head struct {
+				Kind     string            `json:"kind"`
+				Metadata metav1.ObjectMeta `json:"metadata"`
+			}
+			if err := yaml.Unmarshal([]byte(doc), &head); err != nil {
+				return nil, fmt.Errorf("failed to parse rendered chart template %s: %w", name, err)
+			}
+			if head.Kind == "" {
+				continue // empty or commented out document
+			}
+			if _, ok := head.Metadata.Annotations[helmHookAnnotation]; ok {
+				log.Info("skipping hook of chart template", "kind", head.Kind, "hook", head.Metadata.Name, "template", name)
+				continue
+			}
+			manifests[fmt.Sprintf("%s-%d.yaml", strings.ReplaceAll(name, "/", "_"), i)] = doc
+		}
+	}
+	return manifests, nil
+}
@@ -0,0 +1,128 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kudobuilder/kudo/pkg/engine"
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+)
+
+var chartTemplates = map[string]string{
+	"redis-values.yaml": `name: {{ .Params.NAME }}`,
+	"charts/redis/Chart.yaml": `apiVersion: v2
+name: redis
+version: 0.1.0
+`,
+	"charts/redis/values.yaml": `name: default
+port: 6379
+`,
+	"charts/redis/templates/_helpers.tpl": `{{ define "redis.name" }}{{ .Release.Name }}-{{ .Values.name }}{{ end }}`,
+	"charts/redis/templates/NOTES.txt":    `Thank you for installing redis`,
+	"charts/redis/templates/configmaps.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "redis.name" . }}
+  namespace: {{ .Release.Namespace }}
+data:
+  port: "{{ .Values.port }}"
+---
+# an empty document
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "redis.name" . }}-hook
+  annotations:
+    helm.sh/hook: pre-install
+`,
+}
+
+func TestHelmTask_Run(t *testing.T) {
+	meta := renderer.Metadata{
+		Metadata: engine.Metadata{
+			InstanceName:      "test",
+			InstanceNamespace: "default",
+			OperatorName:      "redis-operator",
+		},
+		PlanName:  "plan",
+		PhaseName: "phase",
+		StepName:  "step",
+	}
+
+	c := fake.NewFakeClientWithScheme(scheme.Scheme)
+	ctx := Context{
+		Client:     c,
+		Enhancer:   &testEnhancer{},
+		Meta:       meta,
+		Templates:  chartTemplates,
+		Parameters: map[string]string{"NAME": "cache"},
+	}
+
+	done, err := HelmTask{Name: "task", Chart: "redis", Values: "redis-values.yaml"}.Run(ctx)
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-cache"}, cm))
+	assert.Equal(t, "6379", cm.Data["port"])
+
+	list := &corev1.ConfigMapList{}
+	assert.NoError(t, c.List(context.TODO(), list))
+	assert.Equal(t, 1, len(list.Items), "the hook is skipped")
+
+	_, err = HelmTask{Name: "task", Chart: "missing"}.Run(ctx)
+	assert.True(t, errors.Is(err, engine.ErrFatalExecution))
+}
+
+func TestApplyTask_IgnoresChartPartials(t *testing.T) {
+	templates := map[string]string{
+		"_labels.tpl": `{{ define "labels" }}app: {{ .Name }}{{ end }}`,
+		"cm.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Name }}
+  namespace: default
+  labels:
+    {{ template "labels" . }}
+`,
+		// Helm functions like tpl are not available to KUDO templates and Helm definitions must not clash with KUDO ones
+		"charts/redis/templates/_labels.tpl": `{{ define "labels" }}{{ tpl .Values.labels . }}{{ end }}`,
+	}
+	for name, t := range chartTemplates {
+		templates[name] = t
+	}
+
+	c := fake.NewFakeClientWithScheme(scheme.Scheme)
+	ctx := Context{
+		Client:    c,
+		Enhancer:  &testEnhancer{},
+		Meta:      renderer.Metadata{Metadata: engine.Metadata{InstanceName: "test", InstanceNamespace: "default"}},
+		Templates: templates,
+	}
+
+	done, err := ApplyTask{Name: "task", Resources: []string{"cm.yaml"}}.Run(ctx)
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test"}, cm))
+	assert.Equal(t, map[string]string{"app": "test"}, cm.Labels)
+}
+
+func TestChartFiles(t *testing.T) {
+	files := ChartFiles(chartTemplates, "redis")
+
+	assert.Equal(t, 5, len(files))
+	assert.Contains(t, files, "Chart.yaml")
+	assert.Contains(t, files, "templates/configmaps.yaml")
+	assert.True(t, IsChartFile("charts/redis/Chart.yaml"))
+	assert.False(t, IsChartFile("redis-values.yaml"))
+}
@@ -3,7 +3,6 @@ package task
 import (
 	"errors"
 	"fmt"
-	"log"
 	"path"
 	"path/filepath"
 	"regexp"
@@ -88,13 +87,13 @@ func (pt PipeTask) Run(ctx Context) (bool, error) {
 	}
 
 	// 6. - Apply pod using the client -
-	podObj, err = apply(podObj, ctx.Client)
+	podObj, err = apply(podObj, ctx.Client, ctx.Logger())
 	if err != nil {
 		return false, err
 	}
 
 	// 7. - Wait for the pod to be ready -
-	err = isHealthy(podObj)
+	err = isHealthy(podObj, ctx.Logger())
 	// once the pod is Ready, it means that its initContainer finished successfully and we can copy
 	// out the generated files. An error during a health check is not treated as task execution error
 	if err != nil {
@@ -102,7 +101,7 @@ func (pt PipeTask) Run(ctx Context) (bool, error) {
 	}
 
 	// 8. - Copy out the pipe files -
-	log.Printf("PipeTask: %s/%s copying pipe files", ctx.Meta.InstanceNamespace, ctx.Meta.InstanceName)
+	ctx.Logger().Info("copying pipe files")
 	fs := afero.NewMemMapFs()
 	pipePod := podObj[0].(*corev1.Pod)
 
@@ -112,7 +111,7 @@ func (pt PipeTask) Run(ctx Context) (bool, error) {
 	}
 
 	// 9. - Create k8s artifacts (ConfigMap/Secret) from the pipe files -
-	log.Printf("PipeTask: %s/%s creating pipe artifacts", ctx.Meta.InstanceNamespace, ctx.Meta.InstanceName)
+	ctx.Logger().Info("creating pipe artifacts")
 	artStr, err := createArtifacts(fs, pt.PipeFiles, ctx.Meta)
 	if err != nil {
 		return false, err
@@ -125,14 +124,14 @@ func (pt PipeTask) Run(ctx Context) (bool, error) {
 	}
 
 	// 11. - Apply artifacts using the client -
-	_, err = apply(artObj, ctx.Client)
+	_, err = apply(artObj, ctx.Client, ctx.Logger())
 	if err != nil {
 		return false, err
 	}
 
 	// 12. - Delete pipe pod -
-	log.Printf("PipeTask: %s/%s deleting pipe pod", ctx.Meta.InstanceNamespace, ctx.Meta.InstanceName)
-	err = delete(podObj, ctx.Client)
+	ctx.Logger().Info("deleting pipe pod")
+	err = delete(podObj, metav1.DeletePropagationForeground, ctx.Client)
 	if err != nil {
 		return false, err
 	}
@@ -272,7 +271,7 @@ func copyFiles(fs afero.Fs, ff []PipeFile, pod *corev1.Pod, ctx Context) error {
 
 	for _, f := range ff {
 		f := f
-		log.Printf("PipeTask: %s/%s copying pipe file %s", ctx.Meta.InstanceNamespace, ctx.Meta.InstanceName, f.File)
+		ctx.Logger().V(1).Info("copying pipe file", "file", f.File)
 		g.Go(func() error {
 			// Check the size of the pipe file first. K87 has a inherent limit on the size of
 			// Secret/ConfigMap, so we avoid unnecessary copying of files that are too big by
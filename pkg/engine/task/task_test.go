@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
@@ -46,6 +47,51 @@ spec:
 			},
 			wantErr: false,
 		},
+		{
+			name: "delete task with propagation policy, wait and selector",
+			taskYaml: `
+name: delete-task
+kind: Delete
+spec:
+    propagationPolicy: Background
+    wait: true
+    selector:
+      kinds:
+        - apiVersion: v1
+          kind: PersistentVolumeClaim
+      matchLabels:
+        app: zk`,
+			want: DeleteTask{
+				Name:              "delete-task",
+				PropagationPolicy: "Background",
+				Wait:              true,
+				Selector: &v1beta1.DeleteSelector{
+					Kinds:       []metav1.TypeMeta{{APIVersion: "v1", Kind: "PersistentVolumeClaim"}},
+					MatchLabels: map[string]string{"app": "zk"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete task with an invalid propagation policy",
+			taskYaml: `
+name: delete-task
+kind: Delete
+spec:
+    propagationPolicy: Sometimes
+    resources:
+      - pod.yaml`,
+			wantErr: true,
+		},
+		{
+			name: "delete task without resources and selector",
+			taskYaml: `
+name: delete-task
+kind: Delete
+spec:
+    wait: true`,
+			wantErr: true,
+		},
 		{
 			name: "dummy task",
 			taskYaml: `
@@ -149,6 +195,31 @@ spec:
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "helm task",
+			taskYaml: `
+name: helm-task
+kind: Helm
+spec:
+    chart: redis
+    values: redis-values.yaml`,
+			want: HelmTask{
+				Name:   "helm-task",
+				Chart:  "redis",
+				Values: "redis-values.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "helm task without a chart",
+			taskYaml: `
+name: helm-task
+kind: Helm
+spec:
+    values: redis-values.yaml`,
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "unknown task",
 			taskYaml: `
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 )
 
 // Metadata contains engine metadata associated with the current operator being executed
@@ -18,6 +20,9 @@ type Metadata struct {
 
 	// the object that will own all the resources created by this execution
 	ResourcesOwner metav1.Object
+
+	// Customization of the rendered resources, as defined by the instance
+	Customization *v1beta1.InstanceCustomization
 }
 
 var (
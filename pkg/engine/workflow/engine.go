@@ -3,10 +3,11 @@ package workflow
 import (
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/kudobuilder/kudo/pkg/engine"
 	"github.com/kudobuilder/kudo/pkg/engine/renderer"
 	"github.com/kudobuilder/kudo/pkg/engine/task"
+	"github.com/kudobuilder/kudo/pkg/metrics"
 )
 
 var (
@@ -63,12 +65,22 @@ func (ap *ActivePlan) taskByName(name string) (*v1beta1.Task, bool) {
 //
 // Furthermore, a transient ERROR during a step execution, means that the next step may be executed if the step strategy
 // is "parallel". In case of a fatal error, it is returned alongside with the new plan status and published on the event bus.
-func Execute(pl *ActivePlan, em *engine.Metadata, c client.Client, enh renderer.Enhancer, currentTime time.Time) (*v1beta1.PlanStatus, error) {
+//
+// The execution of the plan and its phases and steps is recorded in the plan metrics of the metrics package.
+// The passed logger is expected to carry the instance and plan context, the phase, step and task are added to the
+// logger passed to each task in its task.Context. The mapper resolves the scope of kinds looked up by templates, it can
+// be nil if no cluster is available.
+func Execute(pl *ActivePlan, em *engine.Metadata, c client.Client, mapper meta.RESTMapper, enh renderer.Enhancer, currentTime time.Time, log logr.Logger) (*v1beta1.PlanStatus, error) {
 	if pl.Status.IsTerminal() {
-		log.Printf("PlanExecution: %s/%s plan %s is terminal, nothing to do", em.InstanceNamespace, em.InstanceName, pl.Name)
+		log.V(1).Info("plan is terminal, nothing to do")
 		return pl.PlanStatus, nil
 	}
 
+	pm := metrics.TrackPlan(fmt.Sprintf("%s/%s", em.InstanceNamespace, em.InstanceName), em.OperatorName, pl.Name, pl.UID)
+	if pl.Status == v1beta1.ExecutionPending {
+		pm.Start("", "", currentTime)
+	}
+
 	planStatus := pl.PlanStatus.DeepCopy()
 	planStatus.Set(v1beta1.ExecutionInProgress)
 
@@ -91,6 +103,9 @@ func Execute(pl *ActivePlan, em *engine.Metadata, c client.Client, enh renderer.
 			phasesLeft = phasesLeft - 1
 			continue
 		} else if isInProgress(phaseStatus.Status) {
+			if phaseStatus.Status == v1beta1.ExecutionPending {
+				pm.Start(ph.Name, "", currentTime)
+			}
 			phaseStatus.Set(v1beta1.ExecutionInProgress)
 		} else {
 			break
@@ -116,6 +131,9 @@ func Execute(pl *ActivePlan, em *engine.Metadata, c client.Client, enh renderer.
 				delete(stepsLeft, stepStatus.Name)
 				continue
 			} else if isInProgress(stepStatus.Status) {
+				if stepStatus.Status == v1beta1.ExecutionPending {
+					pm.Start(ph.Name, st.Name, currentTime)
+				}
 				stepStatus.Set(v1beta1.ExecutionInProgress)
 			} else {
 				// we are not in progress and not finished. An unexpected error occurred so that we can not proceed to the next phase
@@ -164,11 +182,13 @@ func Execute(pl *ActivePlan, em *engine.Metadata, c client.Client, enh renderer.
 				// - 3.c build task context -
 				ctx := task.Context{
 					Client:     c,
+					Mapper:     mapper,
 					Enhancer:   enh,
 					Meta:       exm,
 					Templates:  pl.Templates,
 					Parameters: pl.Params,
 					Pipes:      pl.Pipes,
+					Log:        log.WithValues("phase", ph.Name, "step", st.Name, "task", tn),
 				}
 
 				// --- 4. Execute the engine task ---
@@ -185,7 +205,8 @@ func Execute(pl *ActivePlan, em *engine.Metadata, c client.Client, enh renderer.
 				case err != nil:
 					message := fmt.Sprintf("A transient error when executing task %s.%s.%s.%s. Will retry. %v", pl.Name, ph.Name, st.Name, t.Name, err)
 					stepStatus.SetWithMessage(v1beta1.ErrorStatus, message)
-					log.Printf("PlanExecution: %s", message)
+					ctx.Logger().Error(err, "transient error when executing task, will retry")
+					metrics.TaskTransientErrors.WithLabelValues(t.Kind).Inc()
 				case done:
 					delete(tasksLeft, t.Name)
 				}
@@ -196,11 +217,12 @@ func Execute(pl *ActivePlan, em *engine.Metadata, c client.Client, enh renderer.
 			// otherwise, if STEPs strategy is parallel or all TASKs are finished, we can go to the next STEP
 			if len(tasksLeft) > 0 {
 				if ph.Strategy == v1beta1.Serial {
-					log.Printf("PlanExecution: '%s' task(s) (instance: %s/%s) of the %s.%s.%s are not ready", mapKeysToString(tasksLeft), em.InstanceNamespace, em.InstanceName, pl.Name, ph.Name, st.Name)
+					log.V(1).Info("tasks are not ready", "phase", ph.Name, "step", st.Name, "tasks", mapKeysToString(tasksLeft))
 					break
 				}
 			} else {
 				stepStatus.Set(v1beta1.ExecutionComplete)
+				pm.Complete(ph.Name, st.Name, currentTime)
 				delete(stepsLeft, stepStatus.Name)
 			}
 		}
@@ -210,18 +232,19 @@ func Execute(pl *ActivePlan, em *engine.Metadata, c client.Client, enh renderer.
 		// otherwise, if PHASEs strategy is parallel or all STEPs are finished, we can go to the next PHASE
 		if len(stepsLeft) > 0 {
 			if pl.Spec.Strategy == v1beta1.Serial {
-				log.Printf("PlanExecution: '%s' step(s) (instance: %s/%s) of the %s.%s are not ready", mapKeysToString(stepsLeft), em.InstanceNamespace, em.InstanceName, pl.Name, ph.Name)
+				log.V(1).Info("steps are not ready", "phase", ph.Name, "steps", mapKeysToString(stepsLeft))
 				break
 			}
 		} else {
 			phaseStatus.Set(v1beta1.ExecutionComplete)
+			pm.Complete(ph.Name, "", currentTime)
 			phasesLeft = phasesLeft - 1
 		}
 	}
 
 	// --- 7. Check if all PHASEs are finished ---
 	if phasesLeft == 0 {
-		log.Printf("PlanExecution: %s/%s all phases of the plan %s are ready", em.InstanceNamespace, em.InstanceName, pl.Name)
+		log.Info("all phases of the plan are ready")
 		planStatus.Set(v1beta1.ExecutionComplete)
 		planStatus.LastFinishedRun = v1.Time{Time: currentTime}
 	}
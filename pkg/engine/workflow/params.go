@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/engine"
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/engine/task"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
+)
+
+// Params generates {{ Params.* }} map of keys and values which is later used during template rendering. Instance
+// parameters override the defaults of the operator version parameters, parameters without a default are empty.
+func Params(parameters map[string]string, definitions []v1beta1.Parameter) map[string]string {
+	params := make(map[string]string)
+
+	for k, v := range parameters {
+		params[k] = v
+	}
+
+	// Merge instance parameter overrides with operator version, if no override exist, use the default one
+	for _, param := range definitions {
+		if _, ok := params[param.Name]; !ok {
+			params[param.Name] = kudo.StringValue(param.Default)
+		}
+	}
+
+	return params
+}
+
+// Pipes generates {{ Pipes.* }} map of keys and values which is later used during template rendering.
+func Pipes(planName string, plan *v1beta1.Plan, tasks []v1beta1.Task, emeta *engine.Metadata) (map[string]string, error) {
+	taskByName := func(name string) (*v1beta1.Task, bool) {
+		for _, t := range tasks {
+			if t.Name == name {
+				return &t, true
+			}
+		}
+		return nil, false
+	}
+
+	pipes := make(map[string]string)
+
+	for _, ph := range plan.Phases {
+		for _, st := range ph.Steps {
+			for _, tn := range st.Tasks {
+				rmeta := renderer.Metadata{
+					Metadata:  *emeta,
+					PlanName:  planName,
+					PhaseName: ph.Name,
+					StepName:  st.Name,
+					TaskName:  tn,
+				}
+
+				if t, ok := taskByName(tn); ok && t.Kind == task.PipeTaskKind {
+					for _, pipe := range t.Spec.PipeTaskSpec.Pipe {
+						if _, ok := pipes[pipe.Key]; ok {
+							return nil, fmt.Errorf("duplicated pipe key %s", pipe.Key)
+						}
+						pipes[pipe.Key] = task.PipeArtifactName(rmeta, pipe.Key)
+					}
+				}
+			}
+		}
+	}
+
+	return pipes, nil
+}
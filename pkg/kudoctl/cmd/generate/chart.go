@@ -0,0 +1,328 @@
+package generate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/reader"
+)
+
+// chartMetadata is the part of a Helm Chart.yaml used to fill the operator.yaml
+type chartMetadata struct {
+	Version     string                `json:"version"`
+	AppVersion  string                `json:"appVersion"`
+	Description string                `json:"description"`
+	Home        string                `json:"home"`
+	Maintainers []*v1beta1.Maintainer `json:"maintainers"`
+}
+
+var (
+	valuesRef  = regexp.MustCompile(`(\$?)\.Values((?:\.[A-Za-z0-9_-]+)+)`)
+	builtinRef = regexp.MustCompile(`\$?\.(Release|Chart|Capabilities|Files|Template)\.[A-Za-z]+`)
+	// builtins maps the Helm built-in objects to their KUDO counterparts
+	builtins = map[string]string{
+		".Release.Name":      ".Name",
+		".Release.Namespace": ".Namespace",
+		".Chart.Name":        ".OperatorName",
+		".Chart.AppVersion":  ".AppVersion",
+	}
+	valuesKeyLine = regexp.MustCompile(`^(\s*)([A-Za-z0-9_.-]+)\s*:`)
+)
+
+// FromChart creates operator package files from a Helm chart directory. The chart values become parameters: nested
+// values are flattened, e.g. "image.pullPolicy" becomes the parameter IMAGE_PULL_POLICY, and comments preceding
+// a value in values.yaml become the parameter description. Chart templates become KUDO templates, with references to
+// values and Helm built-in objects rewritten, and a deploy plan applying all of them is generated.
+//
+// The conversion is best effort: returned warnings point to parts of the chart which need a manual review, like
+// structured values, boolean conditions, hooks or sub-charts.
+func FromChart(fs afero.Fs, dir string, op packages.OperatorFile) (*packages.Files, []string, error) {
+	w := warnings{}
+
+	chartFile, err := afero.ReadFile(fs, filepath.Join(dir, "Chart.yaml"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read chart: %w", err)
+	}
+	meta := chartMetadata{}
+	if err := yaml.Unmarshal(chartFile, &meta); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Chart.yaml: %w", err)
+	}
+	op.Version = meta.Version
+	op.AppVersion = meta.AppVersion
+	op.Description = meta.Description
+	op.URL = meta.Home
+	op.Maintainers = meta.Maintainers
+
+	valuesFile, err := afero.ReadFile(fs, filepath.Join(dir, "values.yaml"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to read chart values: %w", err)
+	}
+	params, err := valuesToParameters(valuesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if exists, _ := afero.DirExists(fs, filepath.Join(dir, "charts")); exists {
+		w.add("sub-charts in charts/ are not converted, they need to be converted separately")
+	}
+
+	templates := map[string]string{}
+	templatesPath := filepath.Join(dir, templatesDir)
+	err = afero.Walk(fs, templatesPath, func(p string, file os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(templatesPath, p)
+		if err != nil {
+			return err
+		}
+		if file.IsDir() {
+			if rel == "tests" {
+				w.add("chart tests in templates/tests are not converted")
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := filepath.Ext(p)
+		if ext != ".yaml" && ext != ".yml" && !renderer.IsPartial(p) {
+			return nil // e.g. NOTES.txt
+		}
+		content, err := afero.ReadFile(fs, p)
+		if err != nil {
+			return err
+		}
+		name := templateName(rel)
+		templates[name] = convertTemplate(name, string(content), params, w)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read chart templates: %w", err)
+	}
+
+	op.Tasks, op.Plans = deployPlan(templates)
+
+	parameters := make([]v1beta1.Parameter, 0, len(params))
+	for _, p := range params {
+		parameters = append(parameters, p.Parameter)
+	}
+	sort.Sort(packages.Parameter(parameters))
+
+	return &packages.Files{
+		Templates: templates,
+		Operator:  &op,
+		Params: &packages.ParamsFile{
+			APIVersion: reader.APIVersion,
+			Parameters: parameters,
+		},
+	}, w.list(), nil
+}
+
+// valueParameter is a parameter converted from a chart value
+type valueParameter struct {
+	v1beta1.Parameter
+	// structured is true for list and map values, which are converted to YAML strings
+	structured bool
+	// boolean is true for boolean values
+	boolean bool
+}
+
+// valuesToParameters flattens the chart values into parameters mapped by their dotted value path
+func valuesToParameters(values []byte) (map[string]valueParameter, error) {
+	vals := map[string]interface{}{}
+	if err := yaml.Unmarshal(values, &vals); err != nil {
+		return nil, fmt.Errorf("failed to parse values.yaml: %w", err)
+	}
+	descriptions := valueDescriptions(values)
+
+	params := map[string]valueParameter{}
+	var flatten func(prefix string, m map[string]interface{}) error
+	flatten = func(prefix string, m map[string]interface{}) error {
+		for k, v := range m {
+			valuePath := strings.TrimPrefix(prefix+"."+k, ".")
+			if nested, ok := v.(map[string]interface{}); ok && len(nested) > 0 {
+				if err := flatten(valuePath, nested); err != nil {
+					return err
+				}
+				continue
+			}
+
+			required := false
+			p := valueParameter{Parameter: v1beta1.Parameter{
+				Name:        parameterName(valuePath),
+				Description: descriptions[valuePath],
+				Required:    &required,
+			}}
+			switch value := v.(type) {
+			case nil:
+			case string:
+				p.Default = &value
+			case bool:
+				p.boolean = true
+				s := strconv.FormatBool(value)
+				p.Default = &s
+			case float64:
+				s := strconv.FormatFloat(value, 'f', -1, 64)
+				p.Default = &s
+			default:
+				p.structured = true
+				b, err := yaml.Marshal(value)
+				if err != nil {
+					return fmt.Errorf("failed to convert value %s: %w", valuePath, err)
+				}
+				s := strings.TrimSpace(string(b))
+				p.Default = &s
+			}
+			params[valuePath] = p
+		}
+		return nil
+	}
+	if err := flatten("", vals); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// valueDescriptions returns the comments directly preceding the values in a values.yaml mapped by the value path
+func valueDescriptions(values []byte) map[string]string {
+	descriptions := map[string]string{}
+
+	type key struct {
+		indent int
+		name   string
+	}
+	var parents []key
+	var comments []string
+
+	scanner := bufio.NewScanner(strings.NewReader(string(values)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			comments = append(comments, strings.TrimSpace(strings.TrimLeft(trimmed, "#")))
+			continue
+		case trimmed == "", strings.HasPrefix(trimmed, "-"):
+			comments = nil
+			continue
+		}
+
+		match := valuesKeyLine.FindStringSubmatch(line)
+		if match == nil {
+			comments = nil
+			continue
+		}
+		indent := len(match[1])
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+		parents = append(parents, key{indent: indent, name: match[2]})
+
+		if len(comments) > 0 {
+			names := make([]string, 0, len(parents))
+			for _, p := range parents {
+				names = append(names, p.name)
+			}
+			descriptions[strings.Join(names, ".")] = strings.Join(comments, " ")
+		}
+		comments = nil
+	}
+	return descriptions
+}
+
+// parameterName converts a dotted value path like "image.pullPolicy" into a parameter name like IMAGE_PULL_POLICY
+func parameterName(valuePath string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range valuePath {
+		switch {
+		case r == '.' || r == '-':
+			b.WriteRune('_')
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			b.WriteRune('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(unicode.ToUpper(r))
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// convertTemplate rewrites references to chart values and Helm built-in objects in a chart template
+func convertTemplate(name, content string, params map[string]valueParameter, w warnings) string {
+	content = valuesRef.ReplaceAllStringFunc(content, func(ref string) string {
+		match := valuesRef.FindStringSubmatch(ref)
+		valuePath := strings.TrimPrefix(match[2], ".")
+
+		p, ok := params[valuePath]
+		switch {
+		case !ok && isValuePrefix(valuePath, params):
+			w.add("%s: %s references a map of values, it has to be converted manually", name, ref)
+			return ref
+		case !ok:
+			w.add("%s: %s is not defined in values.yaml", name, ref)
+		case p.structured:
+			w.add("%s: %s is a structured value which is converted to a YAML string parameter %s", name, ref, p.Name)
+		case p.boolean:
+			w.add("%s: %s is a boolean value, parameter %s is a string and conditions using it have to compare it with \"true\"", name, ref, p.Name)
+		}
+		return match[1] + ".Params." + parameterName(valuePath)
+	})
+
+	content = builtinRef.ReplaceAllStringFunc(content, func(ref string) string {
+		if kudo, ok := builtins[strings.TrimPrefix(ref, "$")]; ok {
+			if strings.HasPrefix(ref, "$") {
+				return "$" + kudo
+			}
+			return kudo
+		}
+		w.add("%s: Helm built-in object %s is not supported by KUDO", name, ref)
+		return ref
+	})
+
+	if strings.Contains(content, "helm.sh/hook") {
+		w.add("%s: Helm hooks are not supported, the hook resources are applied like any other resource", name)
+	}
+	return content
+}
+
+// isValuePrefix returns true if the value path is the parent of a converted value
+func isValuePrefix(valuePath string, params map[string]valueParameter) bool {
+	for p := range params {
+		if strings.HasPrefix(p, valuePath+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// warnings collects unique conversion warnings
+type warnings map[string]bool
+
+func (w warnings) add(format string, a ...interface{}) {
+	w[fmt.Sprintf(format, a...)] = true
+}
+
+func (w warnings) list() []string {
+	l := make([]string, 0, len(w))
+	for warning := range w {
+		l = append(l, warning)
+	}
+	sort.Strings(l)
+	return l
+}
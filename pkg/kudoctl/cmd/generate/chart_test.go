@@ -0,0 +1,151 @@
+package generate
+
+import (
+	"path"
+	"sort"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/reader"
+)
+
+const testValues = `# Number of replicas
+replicaCount: 1
+
+image:
+  # The image repository
+  repository: nginx
+  pullPolicy: IfNotPresent
+
+ingress:
+  enabled: false
+
+resources: {}
+tolerations: []
+`
+
+const testDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "test.fullname" . }}
+  namespace: {{ .Release.Namespace }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+  template:
+    spec:
+      containers:
+        - image: "{{ .Values.image.repository }}:{{ .Chart.AppVersion }}"
+          imagePullPolicy: {{ $.Values.image.pullPolicy }}
+          resources:
+{{ toYaml .Values.resources | indent 12 }}
+`
+
+func writeChart(t *testing.T, fs afero.Fs) {
+	files := map[string]string{
+		"mychart/Chart.yaml": `apiVersion: v2
+name: mychart
+version: 1.2.3
+appVersion: 4.5.6
+description: My chart
+`,
+		"mychart/values.yaml":                          testValues,
+		"mychart/templates/_helpers.tpl":               `{{ define "test.fullname" }}{{ .Release.Name }}{{ end }}`,
+		"mychart/templates/NOTES.txt":                  `Thank you`,
+		"mychart/templates/deployment.yaml":            testDeployment,
+		"mychart/templates/serviceaccount.yaml":        "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: sa\n",
+		"mychart/templates/ingress.yml":                "{{ if .Values.ingress.enabled }}\napiVersion: extensions/v1beta1\nkind: Ingress\n{{ end }}",
+		"mychart/templates/tests/test-connection.yaml": "apiVersion: v1\nkind: Pod\n",
+	}
+	for name, content := range files {
+		if err := afero.WriteFile(fs, name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFromChart(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeChart(t, fs)
+
+	pf, warnings, err := FromChart(fs, "mychart", packages.OperatorFile{Name: "foo", APIVersion: reader.APIVersion})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", pf.Operator.Version)
+	assert.Equal(t, "4.5.6", pf.Operator.AppVersion)
+	assert.Equal(t, "My chart", pf.Operator.Description)
+
+	params := map[string]string{}
+	descriptions := map[string]string{}
+	for _, p := range pf.Params.Parameters {
+		params[p.Name] = *p.Default
+		descriptions[p.Name] = p.Description
+	}
+	assert.Equal(t, map[string]string{
+		"REPLICA_COUNT":     "1",
+		"IMAGE_REPOSITORY":  "nginx",
+		"IMAGE_PULL_POLICY": "IfNotPresent",
+		"INGRESS_ENABLED":   "false",
+		"RESOURCES":         "{}",
+		"TOLERATIONS":       "[]",
+	}, params)
+	assert.Equal(t, "Number of replicas", descriptions["REPLICA_COUNT"])
+	assert.Equal(t, "The image repository", descriptions["IMAGE_REPOSITORY"])
+
+	assert.Equal(t, []string{"_helpers.tpl", "deployment.yaml", "ingress.yaml", "serviceaccount.yaml"}, keys(pf.Templates))
+	assert.Equal(t, `{{ define "test.fullname" }}{{ .Name }}{{ end }}`, pf.Templates["_helpers.tpl"])
+	assert.Contains(t, pf.Templates["deployment.yaml"], "namespace: {{ .Namespace }}")
+	assert.Contains(t, pf.Templates["deployment.yaml"], "replicas: {{ .Params.REPLICA_COUNT }}")
+	assert.Contains(t, pf.Templates["deployment.yaml"], `image: "{{ .Params.IMAGE_REPOSITORY }}:{{ .AppVersion }}"`)
+	assert.Contains(t, pf.Templates["deployment.yaml"], "imagePullPolicy: {{ $.Params.IMAGE_PULL_POLICY }}")
+
+	assert.Equal(t, []string{
+		"chart tests in templates/tests are not converted",
+		"deployment.yaml: .Values.resources is a structured value which is converted to a YAML string parameter RESOURCES",
+		`ingress.yaml: .Values.ingress.enabled is a boolean value, parameter INGRESS_ENABLED is a string and conditions using it have to compare it with "true"`,
+	}, warnings)
+
+	plan := pf.Operator.Plans["deploy"]
+	assert.Equal(t, 1, len(plan.Phases))
+	assert.Equal(t, "rbac", plan.Phases[0].Steps[0].Name)
+	assert.Equal(t, "resources", plan.Phases[0].Steps[1].Name)
+	assert.Equal(t, []string{"deployment.yaml", "ingress.yaml"}, pf.Operator.Tasks[1].Spec.Resources)
+}
+
+func TestFromManifests(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "manifests/crd.yaml", []byte("apiVersion: apiextensions.k8s.io/v1beta1\nkind: CustomResourceDefinition\n"), 0644)
+	_ = afero.WriteFile(fs, "manifests/app/deploy.yml", []byte("apiVersion: apps/v1\nkind: Deployment\n"), 0644)
+	_ = afero.WriteFile(fs, "manifests/app/config.yaml", []byte("apiVersion: v1\nkind: ConfigMap\n---\napiVersion: v1\nkind: Service\n"), 0644)
+	_ = afero.WriteFile(fs, "manifests/README.md", []byte("readme"), 0644)
+
+	pf, err := FromManifests(fs, "manifests", packages.OperatorFile{Name: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app-config.yaml", "app-deploy.yaml", "crd.yaml"}, keys(pf.Templates))
+
+	steps := pf.Operator.Plans["deploy"].Phases[0].Steps
+	assert.Equal(t, 3, len(steps))
+	for i, name := range []string{"crds", "config", "resources"} {
+		assert.Equal(t, name, steps[i].Name)
+		assert.Equal(t, []string{"deploy-" + name}, steps[i].Tasks)
+	}
+	assert.Equal(t, []string{"app-config.yaml"}, pf.Operator.Tasks[1].Spec.Resources)
+
+	assert.NoError(t, Package(fs, "operator", pf, false))
+	exists, _ := afero.Exists(fs, path.Join("operator", "templates", "app-deploy.yaml"))
+	assert.True(t, exists)
+
+	_, err = FromManifests(fs, "operator/templates/missing", packages.OperatorFile{Name: "foo"})
+	assert.Error(t, err)
+}
+
+func keys(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,70 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/reader"
+)
+
+// FromManifests creates operator package files from a directory of plain Kubernetes manifests. Every manifest
+// becomes a template and a deploy plan applying all of them is generated. Manifests in sub-directories are
+// included, their template names are prefixed with the sub-directory path.
+func FromManifests(fs afero.Fs, dir string, op packages.OperatorFile) (*packages.Files, error) {
+	templates := map[string]string{}
+
+	err := afero.Walk(fs, dir, func(path string, file os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if file.IsDir() {
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		templates[templateName(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifests from %s: %w", dir, err)
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no manifests found in %s", dir)
+	}
+
+	op.Tasks, op.Plans = deployPlan(templates)
+	return &packages.Files{
+		Templates: templates,
+		Operator:  &op,
+		Params: &packages.ParamsFile{
+			APIVersion: reader.APIVersion,
+			Parameters: []v1beta1.Parameter{},
+		},
+	}, nil
+}
+
+// templateName returns a flat template name for a file relative to a manifests or chart templates directory
+func templateName(rel string) string {
+	name := strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")
+	if strings.HasSuffix(name, ".yml") {
+		name = strings.TrimSuffix(name, ".yml") + ".yaml"
+	}
+	return name
+}
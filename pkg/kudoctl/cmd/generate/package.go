@@ -0,0 +1,118 @@
+package generate
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/afero"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/engine/task"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+)
+
+const (
+	templatesDir   = "templates"
+	deployPlanName = "deploy"
+)
+
+var kindLine = regexp.MustCompile(`(?m)^kind:\s*["']?([A-Za-z0-9]+)`)
+
+// Package generates a complete operator folder with operator.yaml, params.yaml and all templates of the passed files.
+// Existing files are overwritten if overwrite is set.
+func Package(fs afero.Fs, dir string, pf *packages.Files, overwrite bool) error {
+	err := CanGenerateOperator(fs, dir, overwrite)
+	if err != nil {
+		return err
+	}
+
+	err = fs.MkdirAll(path.Join(dir, templatesDir), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = writeOperator(fs, dir, *pf.Operator)
+	if err != nil {
+		return err
+	}
+
+	err = writeParameters(fs, dir, *pf.Params)
+	if err != nil {
+		return err
+	}
+
+	for name, content := range pf.Templates {
+		fname := path.Join(dir, templatesDir, name)
+		if err := afero.WriteFile(fs, fname, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deployPlan returns the tasks and a deploy plan applying the passed templates. Templates are grouped by the kinds
+// they contain, using the same groups an Apply task uses to order objects (see task.KindGroup). Every group is
+// applied by a separate task in its own step, so that e.g. CRDs are established before custom resources are created.
+// Partials are not applied.
+func deployPlan(templates map[string]string) ([]v1beta1.Task, map[string]v1beta1.Plan) {
+	type group struct {
+		rank      int
+		name      string
+		resources []string
+	}
+	groups := map[string]*group{}
+
+	for name, content := range templates {
+		if renderer.IsPartial(name) {
+			continue
+		}
+
+		rank, groupName := task.KindGroup("")
+		for _, match := range kindLine.FindAllStringSubmatch(content, -1) {
+			// a template is applied with the group of its first applied kind
+			if r, n := task.KindGroup(match[1]); r < rank {
+				rank, groupName = r, n
+			}
+		}
+
+		if _, ok := groups[groupName]; !ok {
+			groups[groupName] = &group{rank: rank, name: groupName}
+		}
+		groups[groupName].resources = append(groups[groupName].resources, name)
+	}
+
+	sorted := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		sort.Strings(g.resources)
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].rank < sorted[j].rank })
+
+	tasks := []v1beta1.Task{}
+	steps := []v1beta1.Step{}
+	for _, g := range sorted {
+		taskName := fmt.Sprintf("%s-%s", deployPlanName, g.name)
+		tasks = append(tasks, v1beta1.Task{
+			Name: taskName,
+			Kind: task.ApplyTaskKind,
+			Spec: v1beta1.TaskSpec{ResourceTaskSpec: v1beta1.ResourceTaskSpec{Resources: g.resources}},
+		})
+		steps = append(steps, v1beta1.Step{Name: g.name, Tasks: []string{taskName}})
+	}
+
+	plans := map[string]v1beta1.Plan{}
+	if len(steps) > 0 {
+		plans[deployPlanName] = v1beta1.Plan{
+			Strategy: v1beta1.Serial,
+			Phases: []v1beta1.Phase{{
+				Name:     deployPlanName,
+				Strategy: v1beta1.Serial,
+				Steps:    steps,
+			}},
+		}
+	}
+	return tasks, plans
+}
@@ -8,18 +8,28 @@ import (
 
 const getExample = `  # Get all available instances
   kubectl kudo get instances 
+
+  # Get all instances using the operator version kafka-1.2.0
+  kubectl kudo get instances --operator-version kafka-1.2.0
+
+  # Get all operators with their installed versions and the number of instances using them
+  kubectl kudo get operators
 `
 
-// newGetCmd creates a command that lists the instances in the cluster
+// newGetCmd creates a command that lists the instances or operators in the cluster
 func newGetCmd() *cobra.Command {
+	var operatorVersion string
+
 	getCmd := &cobra.Command{
-		Use:     "get instances",
-		Short:   "Gets all available instances.",
+		Use:     "get instances|operators",
+		Short:   "Gets all available instances or operators.",
 		Example: getExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return get.Run(args, &Settings)
+			return get.Run(args, operatorVersion, &Settings)
 		},
 	}
 
+	getCmd.Flags().StringVar(&operatorVersion, "operator-version", "", "Only list instances using the given operator version of the namespace, including instances in other namespaces")
+
 	return getCmd
 }
@@ -3,18 +3,27 @@ package get
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"os"
 
 	"github.com/xlab/treeprint"
 
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/env"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/util/kudo"
 )
 
-// Run returns the errors associated with cmd env
-func Run(args []string, settings *env.Settings) error {
+const (
+	instancesArg = "instances"
+	operatorsArg = "operators"
+)
+
+// Run returns the errors associated with cmd env. If an operator version is passed, only the instances using it are
+// listed.
+func Run(args []string, operatorVersion string, settings *env.Settings) error {
 
-	err := validate(args)
+	err := validate(args, operatorVersion)
 	if err != nil {
 		return err
 	}
@@ -24,7 +33,11 @@ func Run(args []string, settings *env.Settings) error {
 		return fmt.Errorf("creating kudo client: %w", err)
 	}
 
-	p, err := getInstances(kc, settings)
+	if args[0] == operatorsArg {
+		return printOperators(os.Stdout, kc, settings)
+	}
+
+	p, err := getInstances(kc, operatorVersion, settings)
 	if err != nil {
 		log.Printf("Error: %v", err)
 	}
@@ -33,25 +46,40 @@ func Run(args []string, settings *env.Settings) error {
 	for _, plan := range p {
 		tree.AddBranch(plan)
 	}
-	fmt.Printf("List of current installed instances in namespace \"%s\":\n", settings.Namespace)
+	if operatorVersion != "" {
+		fmt.Printf("List of current installed instances using operator version \"%s/%s\":\n", settings.Namespace, operatorVersion)
+	} else {
+		fmt.Printf("List of current installed instances in namespace \"%s\":\n", settings.Namespace)
+	}
 	fmt.Println(tree.String())
 	return err
 }
 
-func validate(args []string) error {
+func validate(args []string, operatorVersion string) error {
 	if len(args) != 1 {
-		return errors.New(`expecting exactly one argument - "instances"`)
+		return errors.New(`expecting exactly one argument - "instances" or "operators"`)
+	}
+
+	if args[0] != instancesArg && args[0] != operatorsArg {
+		return fmt.Errorf(`expecting "instances" or "operators" and not %q`, args[0])
 	}
 
-	if args[0] != "instances" {
-		return fmt.Errorf(`expecting "instances" and not %q`, args[0])
+	if args[0] == operatorsArg && operatorVersion != "" {
+		return errors.New("--operator-version can only be used to get instances")
 	}
 
 	return nil
 
 }
 
-func getInstances(kc *kudo.Client, settings *env.Settings) ([]string, error) {
+func getInstances(kc *kudo.Client, operatorVersion string, settings *env.Settings) ([]string, error) {
+	if operatorVersion != "" {
+		instanceList, err := kc.InstancesOfOperatorVersion(operatorVersion, settings.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("getting instances of operator version %s: %w", operatorVersion, err)
+		}
+		return instanceList, nil
+	}
 
 	instanceList, err := kc.ListInstances(settings.Namespace)
 	if err != nil {
@@ -60,3 +88,42 @@ func getInstances(kc *kudo.Client, settings *env.Settings) ([]string, error) {
 
 	return instanceList, nil
 }
+
+// printOperators prints the operators of the namespace with their installed versions, as maintained in the operator
+// status by the KUDO manager, and the number of instances using them
+func printOperators(out io.Writer, kc *kudo.Client, settings *env.Settings) error {
+	operators, err := kc.ListOperators(settings.Namespace)
+	if err != nil {
+		return fmt.Errorf("getting operators: %w", err)
+	}
+
+	tree := treeprint.New()
+	for _, o := range operators {
+		branch := tree.AddBranch(operatorSummary(o))
+		for _, v := range o.Status.Versions {
+			branch.AddNode(versionSummary(v, o.Status.LatestVersion))
+		}
+	}
+	fmt.Fprintf(out, "List of current installed operators in namespace \"%s\":\n", settings.Namespace)
+	fmt.Fprintln(out, tree.String())
+	return nil
+}
+
+func operatorSummary(o v1beta1.Operator) string {
+	if len(o.Status.Versions) == 0 {
+		return fmt.Sprintf("%s (no versions installed)", o.Name)
+	}
+	return fmt.Sprintf("%s (latest %s, %d instance(s), %d outdated)", o.Name, o.Status.LatestVersion, o.Status.Instances, o.Status.OutdatedInstances)
+}
+
+func versionSummary(v v1beta1.InstalledOperatorVersion, latest string) string {
+	summary := fmt.Sprintf("%s (version %s", v.Name, v.Version)
+	if v.AppVersion != "" {
+		summary += fmt.Sprintf(", app version %s", v.AppVersion)
+	}
+	summary += fmt.Sprintf("): %d instance(s)", v.Instances)
+	if v.Version != latest && v.Instances > 0 {
+		summary += ", outdated"
+	}
+	return summary
+}
@@ -1,6 +1,7 @@
 package get
 
 import (
+	"bytes"
 	"testing"
 
 	tassert "github.com/stretchr/testify/assert"
@@ -12,23 +13,29 @@ import (
 	"github.com/kudobuilder/kudo/pkg/client/clientset/versioned/fake"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/env"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/util/kudo"
+	util "github.com/kudobuilder/kudo/pkg/util/kudo"
 )
 
 func TestValidate(t *testing.T) {
 	tests := []struct {
 		arg []string
+		ov  string
 		err string
 	}{
-		{nil, "expecting exactly one argument - \"instances\""},                          // 1
-		{[]string{"arg", "arg2"}, "expecting exactly one argument - \"instances\""},      // 2
-		{[]string{}, "expecting exactly one argument - \"instances\""},                   // 3
-		{[]string{"somethingelse"}, "expecting \"instances\" and not \"somethingelse\""}, // 4
+		{nil, "", "expecting exactly one argument - \"instances\" or \"operators\""},                          // 1
+		{[]string{"arg", "arg2"}, "", "expecting exactly one argument - \"instances\" or \"operators\""},      // 2
+		{[]string{}, "", "expecting exactly one argument - \"instances\" or \"operators\""},                   // 3
+		{[]string{"somethingelse"}, "", "expecting \"instances\" or \"operators\" and not \"somethingelse\""}, // 4
+		{[]string{"operators"}, "kafka-1.2.0", "--operator-version can only be used to get instances"},        // 5
 	}
 
 	for _, tt := range tests {
-		err := validate(tt.arg)
+		err := validate(tt.arg, tt.ov)
 		assert.ErrorContains(t, err, tt.err)
 	}
+
+	assert.NilError(t, validate([]string{"instances"}, "kafka-1.2.0"))
+	assert.NilError(t, validate([]string{"operators"}, ""))
 }
 
 func newTestClient() *kudo.Client {
@@ -43,7 +50,8 @@ func TestGetInstances(t *testing.T) {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Labels: map[string]string{
-				"operator": "test",
+				"operator":                "test",
+				util.OperatorVersionLabel: "test-1.0",
 			},
 			Name: "test",
 		},
@@ -64,8 +72,49 @@ func TestGetInstances(t *testing.T) {
 		if _, err := kc.InstallInstanceObjToCluster(testInstance, "default"); err != nil {
 			t.Fatal(err)
 		}
-		instanceList, err := getInstances(kc, env.DefaultSettings)
+		instanceList, err := getInstances(kc, "", env.DefaultSettings)
 		assert.NilError(t, err)
 		tassert.EqualValues(t, tt.instances, instanceList, "missing instances")
+
+		instanceList, err = getInstances(kc, "test-1.0", env.DefaultSettings)
+		assert.NilError(t, err)
+		tassert.EqualValues(t, tt.instances, instanceList, "missing instances of operator version")
+
+		instanceList, err = getInstances(kc, "test-2.0", env.DefaultSettings)
+		assert.NilError(t, err)
+		tassert.Empty(t, instanceList)
 	}
 }
+
+func TestPrintOperators(t *testing.T) {
+	kafka := &v1beta1.Operator{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka"},
+		Status: v1beta1.OperatorStatus{
+			Versions: []v1beta1.InstalledOperatorVersion{
+				{Name: "kafka-1.2.0", Version: "1.2.0", AppVersion: "2.4.0", Instances: 1},
+				{Name: "kafka-1.3.0", Version: "1.3.0", AppVersion: "2.5.0", Instances: 2},
+			},
+			LatestVersion:     "1.3.0",
+			Instances:         3,
+			OutdatedInstances: 1,
+		},
+	}
+	zookeeper := &v1beta1.Operator{ObjectMeta: metav1.ObjectMeta{Name: "zookeeper"}}
+
+	kc := newTestClient()
+	for _, o := range []*v1beta1.Operator{kafka, zookeeper} {
+		if _, err := kc.InstallOperatorObjToCluster(o, "default"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	assert.NilError(t, printOperators(&out, kc, env.DefaultSettings))
+	// treeprint indents nested branches with non-breaking spaces
+	tassert.Equal(t, "List of current installed operators in namespace \"default\":\n"+
+		".\n"+
+		"├── kafka (latest 1.3.0, 3 instance(s), 1 outdated)\n"+
+		"│\u00a0\u00a0 ├── kafka-1.2.0 (version 1.2.0, app version 2.4.0): 1 instance(s), outdated\n"+
+		"│\u00a0\u00a0 └── kafka-1.3.0 (version 1.3.0, app version 2.5.0): 2 instance(s)\n"+
+		"└── zookeeper (no versions installed)\n\n", out.String())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
+	configv1beta1 "github.com/kudobuilder/kudo/pkg/apis/config/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/kube"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/kudohome"
@@ -34,6 +35,20 @@ or '--version' which will replace the version designation on the standard image.
 
 To dump a manifest containing the KUDO deployment YAML, combine the '--dry-run' and '--output=yaml' flags.
 
+For high availability, run several KUDO manager replicas with '--replicas'. The replicas are spread across nodes and
+elect a leader which runs the controllers, while webhooks are served by all replicas.
+
+By default, KUDO watches all namespaces and its service account is bound to the cluster-admin role. To limit KUDO to
+some namespaces, pass them with '--watch-namespaces'. KUDO then only needs a role in each of these namespaces and in
+its own namespace. The watched namespaces have to exist, they are labeled with 'kudo.dev/watched-by' so that the
+instance validation webhook only receives instances of these namespaces. When writing the manifests with '--dry-run',
+add this label to the watched namespaces yourself.
+
+The KUDO manager is configured by a configuration file which is generated by 'kudo init' and mounted from the
+kudo-manager-config config map. Pass your own configuration with '--manager-config' to e.g. change the sync period,
+the number of concurrent reconciles or the log format. Webhooks, watched namespaces and leader election are always
+set by 'kudo init'.
+
 Running 'kudo init' on server-side is idempotent - it skips manifests already applied to the cluster in previous runs
 and finishes with success if KUDO is already installed.
 `
@@ -51,25 +66,34 @@ and finishes with success if KUDO is already installed.
   kubectl kudo init --crd-only --dry-run --output yaml | kubectl delete -f -
   # pass existing serviceaccount 
   kubectl kudo init --service-account testaccount
+  # install three KUDO manager replicas
+  kubectl kudo init --replicas 3
+  # install KUDO watching only the namespaces team-a and team-b
+  kubectl kudo init --watch-namespaces team-a,team-b
+  # install KUDO with a custom manager configuration
+  kubectl kudo init --manager-config kudo-manager.yaml
 `
 )
 
 type initCmd struct {
-	out            io.Writer
-	fs             afero.Fs
-	image          string
-	dryRun         bool
-	output         string
-	version        string
-	ns             string
-	serviceAccount string
-	wait           bool
-	timeout        int64
-	clientOnly     bool
-	crdOnly        bool
-	home           kudohome.Home
-	client         *kube.Client
-	webhooks       string
+	out             io.Writer
+	fs              afero.Fs
+	image           string
+	dryRun          bool
+	output          string
+	version         string
+	ns              string
+	serviceAccount  string
+	wait            bool
+	timeout         int64
+	clientOnly      bool
+	crdOnly         bool
+	home            kudohome.Home
+	client          *kube.Client
+	webhooks        string
+	replicas        int32
+	watchNamespaces []string
+	managerConfig   string
 }
 
 func newInitCmd(fs afero.Fs, out io.Writer) *cobra.Command {
@@ -105,6 +129,9 @@ func newInitCmd(fs afero.Fs, out io.Writer) *cobra.Command {
 	f.Int64Var(&i.timeout, "wait-timeout", 300, "Wait timeout to be used")
 	f.StringVar(&i.webhooks, "webhook", "", "List of webhooks to install separated by commas (One of: InstanceValidation)")
 	f.StringVarP(&i.serviceAccount, "service-account", "", "", "Override for the default serviceAccount kudo-manager")
+	f.Int32Var(&i.replicas, "replicas", 1, "Number of KUDO manager replicas")
+	f.StringSliceVar(&i.watchNamespaces, "watch-namespaces", nil, "List of namespaces KUDO is limited to separated by commas (default all namespaces)")
+	f.StringVar(&i.managerConfig, "manager-config", "", "Path to a KUDO manager configuration file used as base of the generated configuration")
 
 	return cmd
 }
@@ -125,6 +152,9 @@ func (initCmd *initCmd) validate(flags *flag.FlagSet) error {
 	if flags.Changed("wait-timeout") && !initCmd.wait {
 		return errors.New("wait-timeout is only useful when using the flag '--wait'")
 	}
+	if initCmd.replicas < 1 {
+		return errors.New("replicas must be at least 1")
+	}
 	if initCmd.webhooks != "" && initCmd.webhooks != "InstanceValidation" {
 		return errors.New("webhooks can be only empty or contain a single string 'InstanceValidation'. No other webhooks supported")
 	}
@@ -135,6 +165,17 @@ func (initCmd *initCmd) validate(flags *flag.FlagSet) error {
 // run initializes local config and installs KUDO manager to Kubernetes cluster.
 func (initCmd *initCmd) run() error {
 	opts := kudoinit.NewOptions(initCmd.version, initCmd.ns, initCmd.serviceAccount, webhooksArray(initCmd.webhooks))
+	if initCmd.replicas > 0 {
+		opts.Replicas = initCmd.replicas
+	}
+	opts.WatchNamespaces = initCmd.watchNamespaces
+	if initCmd.managerConfig != "" {
+		cfg, err := initCmd.readManagerConfig()
+		if err != nil {
+			return err
+		}
+		opts.ManagerConfig = cfg
+	}
 	// if image provided switch to it.
 	if initCmd.image != "" {
 		opts.Image = initCmd.image
@@ -189,6 +230,22 @@ func (initCmd *initCmd) run() error {
 	return nil
 }
 
+// readManagerConfig reads and validates the manager configuration file passed to init
+func (initCmd *initCmd) readManagerConfig() (*configv1beta1.ManagerConfiguration, error) {
+	data, err := afero.ReadFile(initCmd.fs, initCmd.managerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manager configuration: %v", err)
+	}
+	cfg := configv1beta1.NewManagerConfiguration()
+	if err := cfg.Load(data); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manager configuration %s: %v", initCmd.managerConfig, err)
+	}
+	return cfg, nil
+}
+
 func webhooksArray(webhooksAsStr string) []string {
 	if webhooksAsStr == "" {
 		return []string{}
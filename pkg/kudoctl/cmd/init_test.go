@@ -127,6 +127,9 @@ func TestInitCmd_yamlOutput(t *testing.T) {
 		{"yaml output", "deploy-kudo.yaml", map[string]string{"dry-run": "true", "output": "yaml"}},
 		{"service account", "deploy-kudo-sa.yaml", map[string]string{"dry-run": "true", "output": "yaml", "service-account": "safoo", "namespace": "foo"}},
 		{"with webhook", "deploy-kudo-webhook.yaml", map[string]string{"dry-run": "true", "output": "yaml", "webhook": "InstanceValidation"}},
+		{"replicas", "deploy-kudo-replicas.yaml", map[string]string{"dry-run": "true", "output": "yaml", "replicas": "3"}},
+		{"watch namespaces", "deploy-kudo-watch-namespaces.yaml", map[string]string{"dry-run": "true", "output": "yaml", "watch-namespaces": "team-a,team-b"}},
+		{"watch namespaces with webhook", "deploy-kudo-watch-namespaces-webhook.yaml", map[string]string{"dry-run": "true", "output": "yaml", "watch-namespaces": "team-a,team-b", "webhook": "InstanceValidation"}},
 	}
 
 	for _, tt := range tests {
@@ -163,8 +166,55 @@ func TestInitCmd_yamlOutput(t *testing.T) {
 
 }
 
+func TestInitCmd_managerConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	config := `apiVersion: config.kudo.dev/v1beta1
+kind: ManagerConfiguration
+syncPeriod: 1h
+maxConcurrentReconciles: 4
+metricsBindAddress: ":9090"
+healthProbeBindAddress: "0"
+watchNamespaces:
+- ignored
+logging:
+  format: console
+`
+	if err := afero.WriteFile(fs, "kudo-manager.yaml", []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	initCmd := newInitCmd(fs, out)
+	Settings.AddFlags(initCmd.Flags())
+	flags := map[string]string{"dry-run": "true", "output": "yaml", "manager-config": "kudo-manager.yaml", "webhook": "InstanceValidation"}
+	for f, value := range flags {
+		if err := initCmd.Flags().Set(f, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := initCmd.RunE(initCmd, []string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	gp := filepath.Join("testdata", "deploy-kudo-manager-config.yaml.golden")
+	if *updateGolden {
+		t.Logf("updating golden file %s", gp)
+		if err := ioutil.WriteFile(gp, out.Bytes(), 0644); err != nil {
+			t.Fatalf("failed to update golden file: %s", err)
+		}
+	}
+	g, err := ioutil.ReadFile(gp)
+	if err != nil {
+		t.Fatalf("failed reading .golden: %s", err)
+	}
+	assert.Equal(t, string(g), out.String(), "for golden file: %s", gp)
+}
+
 func TestNewInitCmd(t *testing.T) {
 	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "invalid.yaml", []byte("apiVersion: config.kudo.dev/v1beta1\nkind: ManagerConfiguration\nmaxConcurrentReconciles: 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
 	var tests = []struct {
 		name         string
 		flags        map[string]string
@@ -175,6 +225,9 @@ func TestNewInitCmd(t *testing.T) {
 		{name: "name and version together invalid", flags: map[string]string{"kudo-image": "foo", "version": "bar"}, errorMessage: "specify either 'kudo-image' or 'version', not both"},
 		{name: "crd-only and wait together invalid", flags: map[string]string{"crd-only": "true", "wait": "true"}, errorMessage: "wait is not allowed with crd-only"},
 		{name: "wait-timeout invalid without wait", flags: map[string]string{"wait-timeout": "400"}, errorMessage: "wait-timeout is only useful when using the flag '--wait'"},
+		{name: "replicas invalid", flags: map[string]string{"replicas": "0"}, errorMessage: "replicas must be at least 1"},
+		{name: "manager config missing", flags: map[string]string{"manager-config": "missing.yaml", "dry-run": "true"}, errorMessage: "failed to read manager configuration: open missing.yaml: file does not exist"},
+		{name: "manager config invalid", flags: map[string]string{"manager-config": "invalid.yaml", "dry-run": "true"}, errorMessage: "invalid manager configuration invalid.yaml: max concurrent reconciles must be at least 1, got 0"},
 	}
 
 	for _, tt := range tests {
@@ -15,7 +15,10 @@ provide a list of parameters from a remote operator given a url or repository al
 `
 
 const packageExamples = `  kubectl kudo package create [operator folder]
+  kubectl kudo package images [operator]
   kubectl kudo package params list [operator]
+  kubectl kudo package plan-graph [operator]
+  kubectl kudo package render [operator]
   kubectl kudo package verify [operator]
 `
 
@@ -29,8 +32,11 @@ func newPackageCmd(fs afero.Fs, out io.Writer) *cobra.Command {
 	}
 
 	cmd.AddCommand(newPackageCreateCmd(fs, out))
+	cmd.AddCommand(newPackageImagesCmd(fs, out))
 	cmd.AddCommand(newPackageNewCmd(fs, out))
 	cmd.AddCommand(newPackageParamsCmd(fs, out))
+	cmd.AddCommand(newPackagePlanGraphCmd(fs, out))
+	cmd.AddCommand(newPackageRenderCmd(fs, out))
 	cmd.AddCommand(newPackageVerifyCmd(fs, out))
 
 	return cmd
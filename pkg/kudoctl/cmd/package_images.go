@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/cmd/install"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/env"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/render"
+	pkgresolver "github.com/kudobuilder/kudo/pkg/kudoctl/packages/resolver"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/util/repo"
+)
+
+type packageImagesCmd struct {
+	fs             afero.Fs
+	out            io.Writer
+	path           string
+	parameters     []string
+	RepoName       string
+	PackageVersion string
+}
+
+const (
+	pkgImagesDesc = `List all container images used by an operator package.
+
+The templates of all plans are rendered offline with the default parameter values, which can be overridden with
+--parameter. The listed images can e.g. be copied to a registry mirror for air-gapped clusters.
+`
+
+	pkgImagesExample = `# list images of local-folder (where local-folder is a folder in the current directory)
+  kubectl kudo package images local-folder
+
+  # list images of zookeeper (where zookeeper is name of package in KUDO repository)
+  kubectl kudo package images zookeeper -p ZOOKEEPER_VERSION=3.5.6`
+)
+
+// newPackageImagesCmd creates the package images command
+func newPackageImagesCmd(fs afero.Fs, out io.Writer) *cobra.Command {
+	images := &packageImagesCmd{fs: fs, out: out}
+
+	cmd := &cobra.Command{
+		Use:     "images [operator]",
+		Short:   "List container images used by an operator",
+		Long:    pkgImagesDesc,
+		Example: pkgImagesExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOperatorArg(args); err != nil {
+				return err
+			}
+			images.path = args[0]
+			return images.run(&Settings)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringArrayVarP(&images.parameters, "parameter", "p", nil, "The parameter name and value separated by '='")
+	f.StringVar(&images.RepoName, "repo", "", "Name of repository configuration to use. (default defined by context)")
+	f.StringVar(&images.PackageVersion, "version", "", "A specific package version on the official GitHub repo. (default to the most recent)")
+
+	return cmd
+}
+
+func (c *packageImagesCmd) run(settings *env.Settings) error {
+	parameters, err := install.GetParameterMap(c.parameters)
+	if err != nil {
+		return fmt.Errorf("could not parse arguments: %w", err)
+	}
+
+	repository, err := repo.ClientFromSettings(c.fs, settings.Home, c.RepoName)
+	if err != nil {
+		return fmt.Errorf("could not build operator repository: %w", err)
+	}
+	clog.V(4).Printf("repository used %s", repository)
+
+	clog.V(3).Printf("getting package pkg files for %v with version: %v", c.path, c.PackageVersion)
+	resolver := pkgresolver.New(repository)
+	pf, err := resolver.Resolve(c.path, c.PackageVersion)
+	if err != nil {
+		return fmt.Errorf("failed to resolve package files for operator: %s: %w", c.path, err)
+	}
+
+	var objs []runtime.Object
+	for _, plan := range render.PlanNames(pf.Files) {
+		steps, err := render.Plan(pf.Files, plan, render.Options{Parameters: parameters})
+		if err != nil {
+			return fmt.Errorf("failed to render plan %s: %w", plan, err)
+		}
+		for _, s := range steps {
+			for _, t := range s.Tasks {
+				objs = append(objs, t.Objects...)
+			}
+		}
+	}
+
+	images, err := renderer.ContainerImages(objs)
+	if err != nil {
+		return err
+	}
+	for _, i := range images {
+		fmt.Fprintln(c.out, i)
+	}
+	return nil
+}
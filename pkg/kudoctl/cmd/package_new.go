@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/Masterminds/semver"
@@ -20,15 +21,23 @@ const (
 
 	pkgNewExample = `  # Create a new KUDO operator name foo 
   kubectl kudo package new foo
+
+  # Create a new KUDO operator from an existing Helm chart
+  kubectl kudo package new foo --from-chart ./mychart
+
+  # Create a new KUDO operator from a directory of Kubernetes manifests
+  kubectl kudo package new foo --from-manifests ./manifests
 `
 )
 
 type packageNewCmd struct {
-	name        string
-	out         io.Writer
-	fs          afero.Fs
-	interactive bool
-	overwrite   bool
+	name          string
+	out           io.Writer
+	fs            afero.Fs
+	interactive   bool
+	overwrite     bool
+	fromChart     string
+	fromManifests string
 }
 
 // newPackageNewCmd creates an operator package on the file system
@@ -55,6 +64,8 @@ func newPackageNewCmd(fs afero.Fs, out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.BoolVarP(&pkg.interactive, "interactive", "i", false, "Interactively create operator")
 	f.BoolVarP(&pkg.overwrite, "overwrite", "w", false, "overwrite existing directory and operator.yaml file")
+	f.StringVar(&pkg.fromChart, "from-chart", "", "Create the operator from a Helm chart directory, converting its values into parameters")
+	f.StringVar(&pkg.fromManifests, "from-manifests", "", "Create the operator from a directory of Kubernetes manifests with a default deploy plan")
 	return cmd
 }
 
@@ -65,6 +76,23 @@ func (pkg *packageNewCmd) run() error {
 	kudoDefault := version.Get().GitVersion
 	apiVersionDefault := reader.APIVersion
 
+	if pkg.fromChart != "" || pkg.fromManifests != "" {
+		if pkg.fromChart != "" && pkg.fromManifests != "" {
+			return errors.New("only one of --from-chart and --from-manifests can be used")
+		}
+		if pkg.interactive {
+			return errors.New("interactive mode can not be used together with --from-chart or --from-manifests")
+		}
+
+		op := packages.OperatorFile{
+			Name:        pkg.name,
+			APIVersion:  apiVersionDefault,
+			Version:     ovDefault,
+			KUDOVersion: kudoDefault,
+		}
+		return pkg.importPackage(pathDefault, op)
+	}
+
 	if !pkg.interactive {
 		op := packages.OperatorFile{
 			Name:        pkg.name,
@@ -139,3 +167,29 @@ func (pkg *packageNewCmd) run() error {
 
 	return generate.Operator(pkg.fs, path, op, pkg.overwrite)
 }
+
+// importPackage generates the operator from an existing Helm chart or manifests directory
+func (pkg *packageNewCmd) importPackage(path string, op packages.OperatorFile) error {
+	var files *packages.Files
+	var warnings []string
+	var err error
+
+	if pkg.fromChart != "" {
+		files, warnings, err = generate.FromChart(pkg.fs, pkg.fromChart, op)
+	} else {
+		files, err = generate.FromManifests(pkg.fs, pkg.fromManifests, op)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := generate.Package(pkg.fs, path, files, pkg.overwrite); err != nil {
+		return err
+	}
+
+	for _, w := range warnings {
+		fmt.Fprintf(pkg.out, "Warning: %s\n", w)
+	}
+	fmt.Fprintf(pkg.out, "operator %s created in %s with %d templates and %d parameters\n", op.Name, path, len(files.Templates), len(files.Params.Parameters))
+	return nil
+}
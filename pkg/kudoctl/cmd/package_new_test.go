@@ -97,3 +97,23 @@ func TestPackageNew_Overwrite(t *testing.T) {
 	err = cmd.RunE(cmd, []string{"newop"})
 	assert.Nil(t, err)
 }
+
+func TestPackageNew_FromManifests(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	out := &bytes.Buffer{}
+	_ = afero.WriteFile(fs, "manifests/deploy.yaml", []byte("apiVersion: apps/v1\nkind: Deployment\n"), 0644)
+
+	cmd := newPackageNewCmd(fs, out)
+	_ = cmd.Flags().Set("from-manifests", "manifests")
+	_ = cmd.Flags().Set("from-chart", "chart")
+	err := cmd.RunE(cmd, []string{"newop"})
+	assert.EqualError(t, err, "only one of --from-chart and --from-manifests can be used")
+
+	cmd = newPackageNewCmd(fs, out)
+	_ = cmd.Flags().Set("from-manifests", "manifests")
+	err = cmd.RunE(cmd, []string{"newop"})
+	assert.Nil(t, err)
+
+	exists, _ := afero.Exists(fs, filepath.Join("operator", "templates", "deploy.yaml"))
+	assert.True(t, exists)
+}
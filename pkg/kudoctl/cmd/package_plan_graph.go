@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/env"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/graph"
+	pkgresolver "github.com/kudobuilder/kudo/pkg/kudoctl/packages/resolver"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/util/repo"
+)
+
+type packagePlanGraphCmd struct {
+	fs             afero.Fs
+	out            io.Writer
+	path           string
+	plans          []string
+	format         string
+	RepoName       string
+	PackageVersion string
+}
+
+const (
+	pkgPlanGraphDesc = `Print a graph of the plans of an operator package.
+
+The graph shows the phases, steps and tasks of the plans with their strategies, the templates used by the tasks and
+the parameters triggering the plans. Parameters without a trigger, which run the update or deploy plan, are not shown.
+The graph can be rendered with Graphviz (--format dot) or Mermaid (--format mermaid).
+`
+
+	pkgPlanGraphExample = `# render the plans of local-folder (where local-folder is a folder in the current directory) with Graphviz
+  kubectl kudo package plan-graph local-folder | dot -Tsvg > plans.svg
+
+  # print the deploy plan of zookeeper (where zookeeper is name of package in KUDO repository) as Mermaid graph
+  kubectl kudo package plan-graph zookeeper --plan deploy --format mermaid`
+)
+
+// newPackagePlanGraphCmd creates the package plan-graph command
+func newPackagePlanGraphCmd(fs afero.Fs, out io.Writer) *cobra.Command {
+	g := &packagePlanGraphCmd{fs: fs, out: out}
+
+	cmd := &cobra.Command{
+		Use:     "plan-graph [operator]",
+		Short:   "Print a graph of the operator plans",
+		Long:    pkgPlanGraphDesc,
+		Example: pkgPlanGraphExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOperatorArg(args); err != nil {
+				return err
+			}
+			g.path = args[0]
+			return g.run(&Settings)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringArrayVar(&g.plans, "plan", nil, "The plans to include. (defaults to all plans)")
+	f.StringVar(&g.format, "format", string(graph.DOT), fmt.Sprintf("The output format, %s or %s.", graph.DOT, graph.Mermaid))
+	f.StringVar(&g.RepoName, "repo", "", "Name of repository configuration to use. (default defined by context)")
+	f.StringVar(&g.PackageVersion, "version", "", "A specific package version on the official GitHub repo. (default to the most recent)")
+
+	return cmd
+}
+
+func (c *packagePlanGraphCmd) run(settings *env.Settings) error {
+	repository, err := repo.ClientFromSettings(c.fs, settings.Home, c.RepoName)
+	if err != nil {
+		return fmt.Errorf("could not build operator repository: %w", err)
+	}
+	clog.V(4).Printf("repository used %s", repository)
+
+	clog.V(3).Printf("getting package pkg files for %v with version: %v", c.path, c.PackageVersion)
+	resolver := pkgresolver.New(repository)
+	pf, err := resolver.Resolve(c.path, c.PackageVersion)
+	if err != nil {
+		return fmt.Errorf("failed to resolve package files for operator: %s: %w", c.path, err)
+	}
+
+	return graph.Write(c.out, pf.Files, c.plans, graph.Format(c.format))
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/cmd/install"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/env"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/render"
+	pkgresolver "github.com/kudobuilder/kudo/pkg/kudoctl/packages/resolver"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/util/repo"
+)
+
+type packageRenderCmd struct {
+	fs             afero.Fs
+	out            io.Writer
+	path           string
+	parameters     []string
+	plan           string
+	options        render.Options
+	RepoName       string
+	PackageVersion string
+}
+
+const (
+	pkgRenderDesc = `Render the resources created by a plan of an operator package.
+
+The templates of all tasks of the plan are rendered and enhanced with the KUDO labels and annotations, exactly like
+the KUDO manager does it for an instance, and printed grouped by plan step. No cluster connection is needed: the
+lookup template function is not available and for pipe tasks only the pipe pod is rendered.
+`
+
+	pkgRenderExample = `# render the deploy plan of local-folder (where local-folder is a folder in the current directory)
+  kubectl kudo package render local-folder
+
+  # render a single step of the deploy plan of zookeeper (where zookeeper is name of package in KUDO repository)
+  kubectl kudo package render zookeeper -p NODE_COUNT=5 --phase zookeeper --step deploy`
+)
+
+// newPackageRenderCmd creates the package render command
+func newPackageRenderCmd(fs afero.Fs, out io.Writer) *cobra.Command {
+	r := &packageRenderCmd{fs: fs, out: out}
+
+	cmd := &cobra.Command{
+		Use:     "render [operator]",
+		Short:   "Render the resources of an operator plan",
+		Long:    pkgRenderDesc,
+		Example: pkgRenderExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOperatorArg(args); err != nil {
+				return err
+			}
+			r.path = args[0]
+			return r.run(&Settings)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringArrayVarP(&r.parameters, "parameter", "p", nil, "The parameter name and value separated by '='")
+	f.StringVar(&r.plan, "plan", "deploy", "The plan to render.")
+	f.StringVar(&r.options.Phase, "phase", "", "Render only the steps of this phase.")
+	f.StringVar(&r.options.Step, "step", "", "Render only this step.")
+	f.StringVar(&r.options.InstanceName, "instance", "", "The Instance name. (defaults to Operator name appended with -instance)")
+	f.StringVar(&r.options.Namespace, "namespace", "default", "The Instance namespace.")
+	f.StringVar(&r.RepoName, "repo", "", "Name of repository configuration to use. (default defined by context)")
+	f.StringVar(&r.PackageVersion, "version", "", "A specific package version on the official GitHub repo. (default to the most recent)")
+
+	return cmd
+}
+
+func (c *packageRenderCmd) run(settings *env.Settings) error {
+	var err error
+	c.options.Parameters, err = install.GetParameterMap(c.parameters)
+	if err != nil {
+		return fmt.Errorf("could not parse arguments: %w", err)
+	}
+
+	repository, err := repo.ClientFromSettings(c.fs, settings.Home, c.RepoName)
+	if err != nil {
+		return fmt.Errorf("could not build operator repository: %w", err)
+	}
+	clog.V(4).Printf("repository used %s", repository)
+
+	clog.V(3).Printf("getting package pkg files for %v with version: %v", c.path, c.PackageVersion)
+	resolver := pkgresolver.New(repository)
+	pf, err := resolver.Resolve(c.path, c.PackageVersion)
+	if err != nil {
+		return fmt.Errorf("failed to resolve package files for operator: %s: %w", c.path, err)
+	}
+
+	steps, err := render.Plan(pf.Files, c.plan, c.options)
+	if err != nil {
+		return fmt.Errorf("failed to render plan %s: %w", c.plan, err)
+	}
+
+	for _, s := range steps {
+		fmt.Fprintf(c.out, "# Plan: %s, Phase: %s, Step: %s\n", c.plan, s.Phase, s.Name)
+		for _, t := range s.Tasks {
+			for _, o := range t.Objects {
+				b, err := yaml.Marshal(o)
+				if err != nil {
+					return fmt.Errorf("failed to marshal resource of task %s: %w", t.Name, err)
+				}
+				fmt.Fprintf(c.out, "---\n# Task: %s (%s)\n%s", t.Name, t.Kind, b)
+			}
+		}
+	}
+	return nil
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
-	"github.com/kudobuilder/kudo/pkg/kudoctl/cmd/verify"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/reader"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/verify"
 )
 
 // package verify provides verification or linting checks against the package passed to the command.
@@ -4,15 +4,23 @@ import (
 	"io"
 	"os"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
 	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/cmd/plan"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/simulate"
 )
 
 const (
 	planHistExample = `  # View plan status
   kubectl kudo plan history <operatorVersion> --instance=<instanceName>
+`
+	planSimulateExample = `  # Simulate the deploy plan of a local operator package
+  kubectl kudo plan simulate ./operator
+
+  # Simulate a plan with a StatefulSet that needs three plan executions to become healthy
+  kubectl kudo plan simulate ./operator --plan upgrade --healthy-after StatefulSet/zk-instance=3
 `
 	planStatuExample = `  # View plan status
   kubectl kudo plan status --instance=<instanceName>
@@ -20,7 +28,7 @@ const (
 )
 
 // newPlanCmd creates a new command that shows the plans available for an instance
-func newPlanCmd(out io.Writer) *cobra.Command {
+func newPlanCmd(fs afero.Fs, out io.Writer) *cobra.Command {
 	newCmd := &cobra.Command{
 		Use:   "plan",
 		Short: "View all available plans.",
@@ -28,6 +36,7 @@ func newPlanCmd(out io.Writer) *cobra.Command {
 	}
 
 	newCmd.AddCommand(NewPlanHistoryCmd())
+	newCmd.AddCommand(NewPlanSimulateCmd(fs, out))
 	newCmd.AddCommand(NewPlanStatusCmd(out))
 
 	return newCmd
@@ -50,6 +59,37 @@ func NewPlanHistoryCmd() *cobra.Command {
 	return listCmd
 }
 
+// NewPlanSimulateCmd creates a command that simulates the execution of a plan of an operator package
+func NewPlanSimulateCmd(fs afero.Fs, out io.Writer) *cobra.Command {
+	options := &plan.SimulateOptions{Out: out}
+	simulateCmd := &cobra.Command{
+		Use:   "simulate [operator]",
+		Short: "Simulates the execution of a plan without a cluster.",
+		Long: `Simulates the execution of a plan of an operator package against a fake cluster. Created workloads are marked
+healthy right after their creation unless specified otherwise with --healthy-after. The executed tasks and the
+status changes of the plan are printed. The command fails if the plan fails or can never complete.
+Pipe tasks are not run but simulated as successful tasks.`,
+		Example: planSimulateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOperatorArg(args); err != nil {
+				return err
+			}
+			options.Package = args[0]
+			return plan.Simulate(fs, options, &Settings)
+		},
+	}
+
+	f := simulateCmd.Flags()
+	f.StringVar(&options.Plan, "plan", "deploy", "The plan to simulate.")
+	f.StringArrayVarP(&options.Parameters, "parameter", "p", nil, "The parameter name and value separated by '='")
+	f.StringToIntVar(&options.HealthyAfter, "healthy-after", nil, "Number of plan executions after their creation before objects become healthy, e.g. StatefulSet/zk=3. Use -1 for objects that never become healthy.")
+	f.IntVar(&options.MaxIterations, "max-executions", simulate.DefaultMaxIterations, "Number of plan executions after which the simulation gives up.")
+	f.StringVar(&options.RepoName, "repo", "", "Name of repository configuration to use. (default defined by context)")
+	f.StringVar(&options.PackageVersion, "version", "", "A specific package version on the official GitHub repo. (default to the most recent)")
+
+	return simulateCmd
+}
+
 //NewPlanStatusCmd creates a new command that shows the status of an instance by looking at its current plan
 func NewPlanStatusCmd(out io.Writer) *cobra.Command {
 	options := &plan.Options{Out: out}
@@ -0,0 +1,62 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+
+	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/cmd/install"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/env"
+	pkgresolver "github.com/kudobuilder/kudo/pkg/kudoctl/packages/resolver"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/simulate"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/util/repo"
+)
+
+// SimulateOptions are the options of the plan simulate command
+type SimulateOptions struct {
+	Out            io.Writer
+	Package        string
+	Plan           string
+	Parameters     []string
+	HealthyAfter   map[string]int
+	MaxIterations  int
+	RepoName       string
+	PackageVersion string
+}
+
+// Simulate runs the plan simulate command
+func Simulate(fs afero.Fs, options *SimulateOptions, settings *env.Settings) error {
+	parameters, err := install.GetParameterMap(options.Parameters)
+	if err != nil {
+		return fmt.Errorf("could not parse arguments: %w", err)
+	}
+
+	repository, err := repo.ClientFromSettings(fs, settings.Home, options.RepoName)
+	if err != nil {
+		return fmt.Errorf("could not build operator repository: %w", err)
+	}
+	clog.V(4).Printf("repository used %s", repository)
+
+	resolver := pkgresolver.New(repository)
+	pf, err := resolver.Resolve(options.Package, options.PackageVersion)
+	if err != nil {
+		return fmt.Errorf("failed to resolve package files for operator: %s: %w", options.Package, err)
+	}
+
+	opts := simulate.Options{HealthyAfter: options.HealthyAfter, MaxIterations: options.MaxIterations}
+	opts.Parameters = parameters
+
+	result, err := simulate.Plan(pf.Files, options.Plan, opts)
+	if result != nil {
+		for _, e := range result.Events {
+			fmt.Fprintln(options.Out, e)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(options.Out, "plan %s completed after %d executions\n", options.Plan, result.Iterations)
+	return nil
+}
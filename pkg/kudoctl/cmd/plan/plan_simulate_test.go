@@ -0,0 +1,27 @@
+package plan
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kudobuilder/kudo/pkg/kudoctl/env"
+)
+
+func TestSimulate(t *testing.T) {
+	out := &bytes.Buffer{}
+	options := &SimulateOptions{Out: out, Package: "../../packages/testdata/zk.tgz", Plan: "deploy"}
+
+	err := Simulate(afero.NewOsFs(), options, &env.Settings{})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "ObjectHealthy StatefulSet/zookeeper-instance")
+	assert.Contains(t, out.String(), "plan deploy completed after")
+
+	out.Reset()
+	options.HealthyAfter = map[string]int{"StatefulSet/zookeeper-instance": -1}
+	err = Simulate(afero.NewOsFs(), options, &env.Settings{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "can never complete")
+}
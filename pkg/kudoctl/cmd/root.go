@@ -44,6 +44,9 @@ and serves as an API aggregation layer.
   # Get instances
   kubectl kudo get instances [flags]
 
+  # Get operators with their installed versions
+  kubectl kudo get operators
+
   # View plan status
   kubectl kudo plan status [flags]
 
@@ -60,7 +63,7 @@ and serves as an API aggregation layer.
 	cmd.AddCommand(newUninstallCmd())
 	cmd.AddCommand(newPackageCmd(fs, cmd.OutOrStdout()))
 	cmd.AddCommand(newGetCmd())
-	cmd.AddCommand(newPlanCmd(cmd.OutOrStdout()))
+	cmd.AddCommand(newPlanCmd(fs, cmd.OutOrStdout()))
 	cmd.AddCommand(newRepoCmd(fs, cmd.OutOrStdout()))
 	cmd.AddCommand(newTestCmd())
 	cmd.AddCommand(newVersionCmd())
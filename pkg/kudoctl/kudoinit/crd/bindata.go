@@ -81,7 +81,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _configCrdsKudoDev_instancesYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\xcf\x8e\xd4\x30\x0c\xc6\xef\x7d\x0a\x6b\x1f\x60\xd0\x8a\x0b\xca\x0d\xc1\x65\x2f\x0b\x62\xd1\x5e\x10\x07\x4f\xe2\x29\x66\xda\x24\xb2\x9d\x11\x08\xf1\xee\x28\x69\x3b\x3b\x53\xca\x9f\xcd\xad\xbf\xe4\x73\xbe\xcf\xa9\x31\xf3\x23\x89\x72\x8a\x0e\x30\x33\x7d\x33\x8a\xf5\x4b\x77\xc7\x57\xba\xe3\xf4\xe2\x74\xbb\x27\xc3\xdb\xee\xc8\x31\x38\x78\x53\xd4\xd2\xf8\x81\x34\x15\xf1\xf4\x96\x0e\x1c\xd9\x38\xc5\x6e\x24\xc3\x80\x86\xae\x03\xf0\x42\x58\xe1\x47\x1e\x49\x0d\xc7\xec\x20\x96\x61\xe8\x00\x22\x8e\xe4\x80\xa3\x1a\x46\x4f\xba\x3b\x96\x90\x76\x81\x4e\x9d\x66\xf2\x55\xda\x4b\x2a\xd9\xc1\x99\x4f\x12\xad\x5b\x00\x93\x85\xbb\x59\xdd\x50\x1e\x8a\xe0\x70\x51\xb2\x51\xe5\xd8\x97\x01\xe5\x89\x77\x00\xea\x53\x26\x07\xf7\xb5\x5e\x46\x4f\xa1\xb2\xb2\x97\x39\xcb\x7c\x87\x1a\x5a\x51\x07\x3f\x7e\x76\x00\x27\x1c\x38\xb4\x28\xd3\x66\xca\x14\x5f\xbf\xbf\x7b\x7c\xf9\xe0\xbf\xd0\x88\x13\x04\xc8\x92\x32\x89\xf1\x52\xa3\xae\x8b\xbe\x9e\x19\x80\x7d\xaf\x16\xd4\x84\x63\x7f\xc6\x2d\xd6\xbf\x0e\x5d\xf6\xf7\xfa\x60\xda\x7f\x25\x6f\x67\xbc\x74\x72\x59\x5b\xe6\xe6\x2c\x82\x96\x64\xc3\x65\x5d\x81\xd4\x0b\xe7\x96\x1d\xde\x5d\x9f\x6d\x77\xf0\x81\x49\x01\x41\xe8\x40\x42\xd1\x13\x58\x02\x5c\xb6\xfc\x5a\xb3\x2a\x0f\xb3\xed\xdd\x8a\x6f\x46\x6a\x31\x50\x70\x24\x23\xd1\xb5\xd3\x3f\x48\xb6\x9b\x33\xbd\xee\x7f\xb4\x07\xfb\x5e\xa8\x47\xa3\xf0\xf0\x9b\xe6\xef\x46\x07\x8c\xcf\x92\x6c\xe0\x15\x3a\x2d\x03\xba\xcc\xe2\x53\x0c\xf4\x9e\xb2\x51\xb8\x5f\x4f\xc9\xcd\xcd\xd5\x7c\xb4\x4f\x9f\x62\x68\xd3\xaa\x0e\x3e\x7d\xae\xbf\xbf\x25\xa1\x30\xbf\xd0\x04\x7f\x05\x00\x00\xff\xff\x1e\x0c\x26\x86\x10\x04\x00\x00")
+var _configCrdsKudoDev_instancesYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x56\x4d\x8f\xdb\x36\x10\xbd\xfb\x57\x3c\xe4\xec\x75\x11\xb4\x28\x0a\xdd\x0a\x2f\x52\x04\x6d\xb3\x8b\xdd\x4d\x2e\x41\x0e\x63\x71\x2c\xb3\x2b\x91\xec\x0c\xe5\x64\x5b\xf4\xbf\x17\xa4\x24\x7f\x49\x76\x9c\x58\xbe\xe8\x71\x66\xf8\xde\xcc\x70\x44\x0a\xf6\x03\x8b\x5a\xef\x0a\x50\xb0\xfc\x25\xb2\x4b\x6f\xba\x78\xfe\x45\x17\xd6\xff\xb0\x7d\xbd\xe2\x48\xaf\x67\xcf\xd6\x99\x02\xcb\x56\xa3\x6f\x1e\x58\x7d\x2b\x25\xdf\xf2\xda\x3a\x1b\xad\x77\xb3\x86\x23\x19\x8a\x54\xcc\x80\x52\x98\x12\xf8\x64\x1b\xd6\x48\x4d\x28\xe0\xda\xba\x9e\x01\x8e\x1a\x2e\x60\x9d\x46\x72\x25\xeb\xe2\xb9\x35\x7e\x61\x78\x3b\xd3\xc0\x65\x72\xad\xc4\xb7\xa1\xc0\x0e\xef\x5c\x34\x2d\x01\x1d\x85\xb7\xbd\x77\x86\x42\xdd\x0a\xd5\x07\x21\x33\xaa\xd6\x55\x6d\x4d\xb2\xc7\x67\x80\x96\x3e\x70\x81\x77\xd4\xb0\x06\x2a\xd9\x24\xac\x5d\x49\xaf\xa5\xdf\x43\x23\xc5\x56\x0b\xfc\xfb\xdf\x0c\xd8\x52\x6d\x4d\x96\xd2\x2d\xfa\xc0\xee\xd7\xfb\xb7\x1f\x7e\x7c\x2c\x37\xdc\x64\xad\x09\x0e\xe2\x03\x4b\xb4\x03\xcf\xf4\x1c\xe4\x75\x87\x01\xf1\x25\x51\xd0\x28\xd6\x55\x3b\x38\xcb\xfa\x9a\xd1\x61\x7e\x87\x5f\x17\xcd\xaf\xfe\xe2\x32\xee\xe0\x21\x93\xc0\x79\x72\xe9\x29\x73\x25\xed\x3f\x07\xf2\xf6\x8f\x61\x2d\xc5\x86\xbc\x84\xe5\xa1\x25\xfc\x1a\x71\xc3\xd8\xa5\x0d\xc2\xce\xb0\xb0\xc1\x5a\x7c\x93\xd7\xd2\x86\x14\xbd\x9c\x04\x05\x22\x37\xa1\xa6\xc8\x3a\x07\x85\x50\x5b\x36\xf0\x0e\xd1\x87\x21\xea\xef\xef\x6f\xef\x50\x7a\xb7\x65\x97\x36\xd3\x05\x96\x1b\x72\x95\x75\x15\x6c\x44\x14\x5b\x55\x2c\x3a\x8e\xbb\x61\xb4\xc1\x50\x64\x84\x9a\xdc\x1c\x5e\x32\x66\x38\xd4\xfe\x25\x63\xb0\x79\x07\x61\x58\x85\xf3\x87\xe6\x8b\x93\x78\x93\x69\xed\xcb\x9f\x75\x4d\x14\x76\x94\xb4\xbb\x63\xdb\x5c\x16\xbb\xb6\xac\x20\x08\xaf\x59\xd8\x95\x8c\xe8\x41\xc3\x52\x79\xea\x73\x12\x1e\x3d\xa5\xab\xe9\x06\x12\x6a\x38\xb2\x68\x71\x9d\xcb\x24\xdc\x1f\x88\x2b\x3a\x8a\xaa\x4a\xb8\xa2\xc8\xe6\x71\xe4\x73\x61\x57\xa4\x82\x9b\x3c\x45\xf4\x62\x4a\x97\x3b\x33\x90\x70\xaa\x26\x1e\x98\xcc\xcb\x1c\xf7\xe2\x2b\x61\x4d\xc7\x7e\x8e\x5b\xae\x84\x0c\x1b\x90\x33\xb8\xaf\xc9\xbd\x21\x5b\xe7\xd3\x0e\x4c\xef\x0a\xc3\x62\xb7\x87\x1d\x9c\xda\xa2\x57\x7e\xe2\x67\x23\x37\x23\x9a\xe7\x73\xd2\x3d\x35\x69\x7c\x12\x72\x6a\x87\xb1\x38\x65\x75\x22\xf7\x8f\x91\x53\x6a\xdd\xc4\x2e\x85\x43\x4c\x40\x7a\xeb\x68\xc2\xaf\x27\x43\x22\xe7\x69\xa7\x15\x65\x3a\x4f\x13\xd9\x48\xff\xb5\x97\x86\x62\x81\x74\x90\x6e\x52\xfc\x49\xab\xc9\xf1\xb4\x7f\x1a\x56\xa5\xea\x1a\x81\x7f\x76\x96\x49\x15\x61\xd3\x36\xe4\x20\x4c\x86\x56\x35\x83\xbf\xa4\x12\x1c\x4d\x9c\xc9\x72\x5c\x45\xc9\xaf\x94\x65\xcb\xe6\x37\x76\x2c\x93\xf3\x6e\x82\xdd\xdd\xc8\x69\x48\xff\x30\x8a\x17\xd5\x7e\xe9\x62\xf6\x87\x59\x79\x52\x8a\xcf\xa4\x28\x7d\x13\xda\x98\x5a\xcf\xcb\xc5\x9a\x58\x17\x7f\xfe\x69\xd2\xa2\x13\x6f\x5d\xe4\x8a\xa7\x62\x08\x93\x5e\xa5\xf8\x21\x1b\x26\x95\x84\x25\x35\x5c\x2f\x49\x19\xd6\xa4\x59\xbc\xb6\x2c\x43\x21\x4a\x6a\x95\x2f\x2b\xce\x1d\xda\x37\x66\xd7\x71\xdf\x53\xb7\xf1\xe8\x39\xc3\xfc\x71\x38\x02\xc7\x19\x9e\xc3\xbb\x44\x14\x4f\xd2\xf2\x1c\x6f\xa8\x4e\xbc\x05\xef\xdd\xb3\xf3\x9f\xdd\xf7\x50\xca\xc9\xfe\x3a\xa1\xa7\x97\xc0\x23\x3a\xb0\x6e\x9f\xd7\x39\x78\x51\x2d\xba\x01\xf6\xed\x44\x84\xff\x6e\xad\xf0\xd1\xb5\xa1\xfb\xdf\x4c\x0c\x9b\x09\xa3\xae\x29\x26\x16\xce\x9c\xb2\x9b\xcc\x67\x04\x9f\x1d\xe9\xc3\x12\x89\xd0\xb1\xbe\x74\xae\xbf\xe9\xfb\x30\x01\x9f\x40\xdb\xfe\x7b\x8c\xe1\xae\xba\x6f\x1c\x2a\x4b\x0e\x91\xcd\xbb\xd3\x5b\xe4\xab\x57\x47\xf7\xc7\xfc\xba\xab\x94\x16\xf8\xf8\x29\x5d\x0f\xa3\x17\x36\xfd\xe7\x58\x0b\x7c\xfc\x34\xfb\x7f\x00\x32\xdb\x6f\x10\x30\x0b\x00\x00")
 
 func configCrdsKudoDev_instancesYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -96,12 +96,12 @@ func configCrdsKudoDev_instancesYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "config/crds/kudo.dev_instances.yaml", size: 1040, mode: os.FileMode(420), modTime: time.Unix(1576740834, 0)}
+	info := bindataFileInfo{name: "config/crds/kudo.dev_instances.yaml", size: 2864, mode: os.FileMode(436), modTime: time.Unix(1792434814, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _configCrdsKudoDev_operatorsYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\xcf\x8e\x13\x31\x0c\xc6\xef\xf3\x14\xd6\x3e\x40\xd1\x8a\x0b\xca\x0d\xc1\x85\xcb\x82\x00\xed\x05\x71\x70\x13\x53\x4c\x27\x7f\x64\x3b\x15\xfb\xf6\x28\x99\x9d\x6e\x3b\x3b\xa5\xaa\x6f\xf9\x39\x9f\xf3\x25\x8e\xb1\xf0\x23\x89\x72\x4e\x0e\xb0\x30\xfd\x35\x4a\x6d\xa5\x9b\xfd\x3b\xdd\x70\x7e\x73\xb8\xdf\x92\xe1\xfd\xb0\xe7\x14\x1c\x7c\xa8\x6a\x39\x7e\x25\xcd\x55\x3c\x7d\xa4\x5f\x9c\xd8\x38\xa7\x21\x92\x61\x40\x43\x37\x00\x78\x21\x6c\xf0\x3b\x47\x52\xc3\x58\x1c\xa4\x3a\x8e\x03\x40\xc2\x48\x0e\x72\x21\x41\xcb\xa2\x9b\x7d\x0d\x79\x13\xe8\x30\x68\x21\xdf\xa4\x3b\xc9\xb5\x38\x38\xf2\x49\xa2\x2d\x05\x30\x59\xf8\xfc\xac\xee\xa8\x8c\x55\x70\x3c\x29\xd9\xa9\x72\xda\xd5\x11\xe5\x85\x0f\x00\xea\x73\x21\x07\x0f\xad\x5e\x41\x4f\x61\x00\x38\xe0\xc8\xa1\x7b\x9d\x4e\xc8\x85\xd2\xfb\x2f\x9f\x1e\xdf\x7e\xf3\xbf\x29\xe2\x04\x01\x8a\xb4\x3a\xc6\xb3\x91\x16\x27\x0f\x77\x64\x00\xf6\xd4\xce\x50\x13\x4e\xbb\x23\xee\xbe\xaf\x6d\x3a\x7d\xc0\xf3\x8d\x79\xfb\x87\xbc\x1d\xf1\xfc\x54\x73\xac\x99\x6b\x11\x48\xbd\x70\xb1\x85\xc3\x8b\x06\xba\xd3\xba\x25\x49\x64\xa4\x2b\x77\xbb\xa2\x0c\xf9\x56\x4d\x44\x4e\x86\x9c\x48\x74\xa9\x61\xa3\xf8\x0a\x5e\xbe\xeb\x14\x14\x91\xc7\xb5\xc4\x7f\x3c\x4c\xd1\xff\xe5\xed\xc2\xd5\xfe\x9c\xa6\x50\x04\x9f\xce\x32\x55\x5e\x39\xbc\x70\xc4\x7a\xf3\x0d\xad\xea\x95\x3f\xb2\x40\x87\x79\xbe\xe7\x51\x7e\x29\x82\xde\x53\x31\x0a\x0f\xcb\x21\xbb\xbb\x3b\x1b\xaf\xbe\xf4\x39\x85\x3e\xec\xea\xe0\xc7\xcf\x36\x51\x96\x85\xc2\x73\xd7\x27\xf8\x2f\x00\x00\xff\xff\xc6\xc7\xac\x12\x4f\x04\x00\x00")
+var _configCrdsKudoDev_operatorsYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\x4b\x6f\xe3\x36\x10\xbe\xeb\x57\x0c\x72\x4e\x5c\x04\xbd\x14\xba\x05\xe9\x25\x45\x10\x07\x4d\x90\x4b\x90\xc3\x58\x9c\x58\xac\x29\x52\x25\x87\x6e\x8d\xc5\xfe\xf7\x05\x45\xbd\x5f\x4e\xb2\x2b\xeb\x60\xcd\x0c\x67\xbe\x79\xf0\x23\xb1\x94\x2f\x64\x9d\x34\x3a\x05\x2c\x25\xfd\xcf\xa4\xc3\x97\xdb\x1c\xfe\x70\x1b\x69\x7e\x3b\x5e\xef\x88\xf1\x3a\x39\x48\x2d\x52\xb8\xf5\x8e\x4d\xf1\x37\x39\xe3\x6d\x46\x7f\xd2\xbb\xd4\x92\xa5\xd1\x49\x41\x8c\x02\x19\xd3\x04\x20\xb3\x84\x41\xf8\x2c\x0b\x72\x8c\x45\x99\x82\xf6\x4a\x25\x00\x1a\x0b\x4a\xc1\x94\x64\x91\x8d\x75\x9b\x83\x17\x66\x23\xe8\x98\xb8\x92\xb2\xb0\x14\x85\xa8\xfc\xa1\x7a\xb4\x52\x33\xd9\x5b\xa3\x7c\xa1\x5d\xd0\x5d\xc1\x5f\x4f\xdb\x87\x47\xe4\x3c\x85\x8d\x63\x64\xef\x36\x0a\x99\x1c\xd7\x19\x24\x00\x00\x82\x5c\x66\x65\x19\x9c\xa4\xf0\x9c\x13\x44\x13\x90\xda\x31\x2a\x45\x02\x8e\x3d\xeb\x08\xe8\xbe\x32\xa9\x04\x7c\x2a\x29\x05\xc7\x56\xea\xfd\x42\xcc\xca\x93\xce\xc8\xcd\xc7\xd3\xbe\xd8\x91\x05\xf3\x0e\xad\x61\xf8\x40\xa5\x9a\xc8\xae\x17\xfa\x6e\xe0\x2c\x46\x0f\x89\xef\xc9\x2e\x84\x37\x9e\x05\x32\x89\xbb\xcf\xc3\xd0\x86\xc1\x3b\xa9\xf7\xc0\x5d\x61\xa6\xe5\xd8\xd6\x11\xce\x43\x6a\xba\xbe\x99\xb4\xbc\xe7\xee\x66\x4f\x3d\x4f\xc1\x73\x02\xb0\xb7\xc6\x97\x29\xb4\x03\x10\x63\x57\x7d\x06\x88\xb3\xb6\xad\xc7\xa4\x12\x95\xca\x5b\x54\xbd\xd9\xa9\xa4\x21\x17\xaf\xd0\x76\xf2\x04\xc0\x65\x26\x54\xf1\x01\x0b\x72\x25\x66\x24\x82\xcc\xef\x6c\x3d\xb4\x75\x8c\x58\xcd\x14\xbe\x7d\x4f\x00\x8e\xa8\xa4\xa8\x66\x36\x2a\x4d\x49\xfa\xe6\xf1\xee\xe5\xf7\xa7\x2c\xa7\xa2\x1a\xea\x20\x2e\x6d\x08\xc3\xb2\xc1\x19\x7e\xbd\x0d\xd4\xca\x26\x63\x54\xc9\x62\x5a\xe7\x8c\xfa\x1b\xa9\x79\xa2\x37\xb3\xfb\x87\x32\x6e\xc5\xcd\x96\x01\x58\x06\x37\x9e\x8c\x81\x62\x01\x40\x78\x0f\x7e\x47\x56\x13\x93\x9b\xc9\xed\xcc\x4a\x61\x3e\xbb\xa6\x40\xa9\x19\xa5\x26\x3b\x82\x0e\x20\x99\x8a\x89\x70\x39\xd7\xaa\xcc\x40\x05\x4a\x35\xa7\x58\xc1\x10\xdf\x30\x83\x5f\x58\x38\xdb\x9f\xbe\x0a\xad\xc5\xd3\x40\xe3\xed\x04\xe1\x42\x88\xf9\xe6\xc7\xe9\x4d\xce\x97\xa4\xdd\xfd\x43\xf1\x88\x31\x5a\x32\x01\xe9\x80\x17\xe8\x23\x52\x07\xea\x53\xe0\x33\xce\x69\xe4\x10\xa6\x1c\xeb\x46\x26\x63\x32\xe9\x9e\x01\x91\xaf\x42\xbd\xef\x5b\x36\x70\x73\xb9\xcf\x97\x59\xfe\x6c\x8d\x01\x26\xac\xba\x8a\x61\x3b\xb6\x5e\x2b\x5b\xcb\xba\x23\x8f\xb0\xc4\xc2\x1f\xa9\x57\x6d\xbf\x0e\xb3\x2e\x92\x03\xb4\x54\x05\x6b\x28\xb5\x55\xc4\x46\xb6\xec\xd9\xd5\x6f\xe4\x36\xf4\x16\x24\xbb\xc8\xd2\x81\x55\x2f\xc1\x58\x41\x96\x04\xec\x4e\xc1\x87\xb4\xe0\xa8\x40\xcd\x32\x6b\xc0\xc1\x7f\x92\xf3\x5e\x92\x13\x9f\x8d\x9d\x42\xc7\xbf\x62\xe3\x63\x59\xd6\x99\xcd\x69\x57\xba\xdf\xa4\x58\xb7\x6c\x7e\xf5\xa0\xb4\x1f\xea\x7c\x7b\xd6\xce\xfa\x83\x71\x3b\x2e\x41\xea\x4c\x79\x11\x16\x75\x4e\xa4\x06\xc3\x39\xd9\xae\xf4\x6e\x25\xb9\xb9\x59\x39\xc7\x6d\x83\xc4\xc2\xb1\xd9\xe6\x14\xfe\x9b\xf7\xb9\xd1\xf9\x4a\x7d\xeb\x76\xa7\x9f\x5f\x6b\xe9\x5f\x2f\x2d\x0d\x4e\xcf\xf8\x5e\x75\x95\x9a\xd1\x85\x9c\x67\xc4\x35\x90\x9f\xe7\xf1\x39\x5c\xf3\x88\xae\xa6\x0c\x93\xac\x06\x1e\x89\x9a\xda\x41\x73\x1f\xef\x4e\x00\xcc\x32\x2a\x99\xc4\xc3\xf8\x02\x75\x71\x31\xb8\x3a\x55\x9f\x99\xd1\xf1\x86\xed\x52\x78\x7d\x0b\x37\x23\x36\x96\x44\xdd\x55\x97\xc2\xeb\x5b\xf2\x63\x00\x21\x82\xcf\x3c\x14\x0c\x00\x00")
 
 func configCrdsKudoDev_operatorsYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -116,12 +116,12 @@ func configCrdsKudoDev_operatorsYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "config/crds/kudo.dev_operators.yaml", size: 1103, mode: os.FileMode(420), modTime: time.Unix(1576740834, 0)}
+	info := bindataFileInfo{name: "config/crds/kudo.dev_operators.yaml", size: 3092, mode: os.FileMode(436), modTime: time.Unix(1792433868, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _configCrdsKudoDev_operatorversionsYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x57\x4d\x6f\x1b\x37\x13\xbe\xeb\x57\x0c\x7c\x79\x2f\x81\x5e\x04\xbd\x14\x7b\x0b\x1c\x14\x30\x90\x3a\x46\x62\x07\x28\xda\x02\x1a\x91\xb3\xda\xa9\xb9\x24\x4b\x72\x25\x0b\x41\xfe\x7b\x31\xa4\x76\x25\xad\x56\xb6\xaa\xea\xa4\x1d\x0e\xe7\xe3\x99\x67\x86\x24\x7a\xfe\x46\x21\xb2\xb3\x15\xa0\x67\x7a\x49\x64\xe5\x2b\xce\x9f\x7f\x8e\x73\x76\xff\x5f\xbf\x5f\x52\xc2\xf7\xb3\x67\xb6\xba\x82\xdb\x2e\x26\xd7\x7e\xa1\xe8\xba\xa0\xe8\x23\xd5\x6c\x39\xb1\xb3\xb3\x96\x12\x6a\x4c\x58\xcd\x00\x54\x20\x14\xe1\x23\xb7\x14\x13\xb6\xbe\x02\xdb\x19\x33\x03\xb0\xd8\x52\x05\xce\x53\xc0\xe4\xc2\xba\x38\x8e\xf3\xe7\x4e\xbb\xb9\xa6\xf5\x2c\x7a\x52\x62\x61\x15\x5c\xe7\x2b\x18\xe4\x65\x67\x94\x25\x80\x12\xc9\xe7\x9d\x91\x5d\xf4\x79\xc5\x9b\x2e\xa0\x39\x75\x90\x17\x23\xdb\x55\x67\x30\x9c\x2c\xcf\x00\xa2\x72\x9e\x2a\xb8\x17\x27\x1e\x15\xe9\x19\xc0\x1a\x0d\xeb\x9c\x47\x71\xeb\x3c\xd9\x0f\x0f\x77\xdf\x7e\xfa\xaa\x1a\x6a\xb1\x08\x01\x7c\x10\x73\x89\xfb\xe8\xe4\x77\x00\xea\x20\x03\x48\x5b\xf1\x11\x53\x60\xbb\x1a\xc4\x39\x99\xb7\x94\x0e\xc1\x3d\x56\x74\xcb\xbf\x48\xa5\x41\xdc\xe3\xd7\xff\xa6\x82\x2b\x01\xfa\x89\x00\xcf\xfa\x97\x9f\x72\xd6\x92\x12\x38\xbe\xe6\xc5\xf1\x46\x4d\x51\x05\xf6\x19\x2f\xb8\x1d\x29\x83\x16\xa6\x50\x04\x84\x44\xad\x37\x98\x48\xef\x9c\x40\x6a\x30\x81\x42\x0b\x4b\x1a\x99\x04\xe8\x22\x69\x48\xae\x77\x2e\x7f\xd1\x02\xdb\x98\xd0\x2a\x02\x57\x43\x6a\x68\xa0\xc2\xfc\xd2\x5c\x7a\x02\x4c\x27\x3f\xc2\x34\xe3\x88\x01\x5b\x4a\x14\xe2\x78\x0b\x27\x6a\x4f\x84\xe7\x81\xef\xb1\xaa\xb1\x33\x69\x6a\x69\x04\xe4\xc7\xa2\x09\x2c\xd0\xed\xb6\x09\x33\x3b\x02\xae\xc1\xba\x7d\x64\xa2\xe2\x83\x5b\xb3\xce\xec\x9d\xfa\x2d\xb7\x19\xae\x1e\xbe\x31\x5c\x6f\x80\x76\x1a\xdd\x25\xe1\x0f\x1f\xa0\xd0\xa7\x2e\x64\x0e\x18\x67\x57\x14\x0e\x55\xa5\x94\x8d\xdb\x9c\x09\x5c\xa2\xde\x27\xba\x61\x63\x60\x49\x99\x1c\xd7\xe5\xc0\xd1\x1b\xdc\x4a\xb7\x5f\x92\xc3\x5e\x7b\x47\xd3\x42\xcb\xe5\x16\x9e\xee\xe2\x55\x01\xd8\xcb\x3c\xdf\x64\x9f\x1c\x73\xfe\x87\xdd\x12\x1b\xd7\x19\x3d\x44\xc2\x36\x6b\xf4\x9d\x75\x06\xc4\x9a\x0d\x41\xed\x02\xd0\x0b\xb6\xde\xd0\x3b\x61\xd0\xa2\x4c\xe4\xdb\xcf\x4f\xf7\x8f\x0b\xb1\x62\xa1\x8b\xc5\x0f\xc1\x1a\x03\xe3\xd2\x08\x63\xce\xd8\xc4\x3c\x71\xc0\xf0\x33\x55\xf0\x87\x2d\xf3\x07\x00\x02\x79\xc3\x0a\x63\x05\xf0\xfd\x3b\xcc\x1f\xa4\x76\x71\x9e\xbd\xc0\x8f\x1f\x37\xd7\x60\x16\xe8\xef\x8e\x03\xe9\x0b\x70\xfb\xb2\x53\xcd\xe1\x70\xcd\x14\x25\xd5\x63\x12\x71\x1c\x2c\x42\x72\xe7\x1a\x86\x86\x96\x92\x72\xa3\x31\x43\xf3\xc4\x77\xe0\x02\x6c\x1a\x4a\x0d\x85\x83\xde\x14\x86\xc4\xae\xae\xf9\xf5\xfe\x5a\x3a\x67\x08\xa7\x60\x4d\x81\x57\x2b\x3a\x19\x4d\x13\x69\x3e\x16\x4d\x60\x4d\x36\x95\x34\x73\x8e\x06\x6d\xa1\xc9\x8a\x52\x04\x7a\x21\xd5\xc9\xb8\xdd\x34\x74\xae\x8c\xa9\x91\xe1\x31\x60\xa3\x1a\xb4\x2b\x01\xad\xf0\xea\x6e\x18\xb7\x79\x30\xce\x0f\x87\xd2\xa2\xf3\x1a\x13\x2d\xce\x18\xe6\x1a\xb0\x04\xb4\xe1\xd4\x94\xa8\x84\x71\x40\x2f\x1c\x93\x60\x28\xf0\x6d\x38\x12\x70\xfa\x5f\x84\x85\x26\x6f\xdc\x76\x71\x45\x57\x9d\x9d\xdd\xfd\x12\x86\x80\xdb\xe3\xa9\x6e\xd0\x9e\xcc\xe7\x23\x88\x1f\x44\x03\x5a\xf4\xb1\xcf\x23\x47\x2f\xc7\x50\xfe\x9c\x3e\x71\x26\x82\x48\x18\x9f\x5f\x77\xf5\x89\x63\x92\x29\x28\x24\xcb\xda\x80\x6b\x64\xb3\xeb\xbf\x52\xa2\xd1\x9d\x67\xec\xfd\xaa\xb3\x68\x7c\x01\x19\x27\x73\xc5\x10\x7b\x63\xe3\xf8\x92\x32\xde\x38\x59\xc3\xab\x0a\xdc\x4f\xc4\xd7\x91\x7f\xec\xb5\xca\x29\x6b\x76\x85\x08\x54\x53\x20\x69\x75\xa9\xf7\x6f\x1f\x7e\xfd\xb4\xb7\x07\xc6\x29\xb9\xc3\x9c\x04\x39\x1a\xc5\x11\x6a\x67\xb4\x0c\x08\xab\x41\x04\x61\x6f\x56\x43\x1d\x5c\x5b\x6a\x7d\x31\x8f\x3a\xbf\x0a\xa8\x85\x14\xbf\x04\xd7\xbe\x9a\xd6\xd3\x91\x6a\x4e\x2b\x66\x76\x8d\x58\x14\xf7\x37\xb0\x62\xfd\xf4\x00\x49\xee\xbf\xf0\xef\x8a\xc2\xad\xff\xd5\xe5\x74\xfa\x2a\x9c\x30\x75\xf1\x8d\x1b\xf3\x48\xd4\xbb\x85\xfe\xd1\xb3\x37\x82\x4a\x91\x4f\xa4\xef\xc7\xef\x90\x9b\x9b\xa3\xa7\x47\xfe\x54\xce\xea\xfc\x2c\x8a\x15\xfc\xfe\xa7\xbc\x2f\x92\x0b\xa4\x7b\xc0\xb3\xf0\x9f\x00\x00\x00\xff\xff\x97\x79\x8b\x24\x79\x0d\x00\x00")
+var _configCrdsKudoDev_operatorversionsYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\x5f\x6f\xdb\x36\x10\x7f\xf7\xa7\x38\xe4\x65\x2f\xa9\x87\x62\xc3\x30\xe8\xad\x48\xd7\xa1\x40\xff\x21\x75\x3a\x0c\x5d\x81\x9c\xa5\x93\xcc\x85\x22\x39\x1e\x65\xc7\x28\xfa\xdd\x87\xa3\x44\xd9\x96\x65\x47\xf0\xba\x28\x0f\x16\x79\x3c\xfe\xee\x77\x7f\x78\x14\x3a\xf5\x89\x3c\x2b\x6b\x32\x40\xa7\xe8\x31\x90\x91\x37\x9e\x3f\xfc\xca\x73\x65\x7f\x5c\x3f\x5f\x52\xc0\xe7\xb3\x07\x65\x8a\x0c\x6e\x1a\x0e\xb6\xbe\x25\xb6\x8d\xcf\xe9\x25\x95\xca\xa8\xa0\xac\x99\xd5\x14\xb0\xc0\x80\xd9\x0c\x20\xf7\x84\x32\xb8\x50\x35\x71\xc0\xda\x65\x60\x1a\xad\x67\x00\x06\x6b\xca\xc0\x3a\xf2\x18\xac\x5f\xb7\x1b\xf3\xfc\xa1\x29\xec\xbc\xa0\xf5\x8c\x1d\xe5\xa2\xa1\xf2\xb6\x71\x19\xf4\xe3\xed\x4a\x96\x29\x80\x16\xc9\xfb\x4e\x49\x87\x3e\xce\x38\xdd\x78\xd4\xc7\x1b\xc4\x49\x56\xa6\x6a\x34\xfa\xa3\xe9\x19\x00\xe7\xd6\x51\x06\xef\xb0\x26\x76\x98\x53\x21\x63\xcd\xd2\x77\x76\x76\x1b\x73\xc0\xd0\x70\x06\x5f\xbf\xcd\x00\xd6\xa8\x55\x11\xcd\x6c\x27\xad\x23\xf3\xe2\xc3\xeb\x4f\x3f\x7d\xcc\x57\x54\x47\x1e\x64\xd8\x79\xd9\x2d\xa8\x04\x5e\x9e\x3d\xce\xfb\x31\x80\xb0\x15\x08\x1c\xbc\x32\x55\x3f\x1c\x6d\x7d\x4a\x68\x9f\xfb\xf4\xd7\x6a\xb3\xcb\xbf\x29\x0f\xfd\x70\xa2\x17\xe0\x34\x38\x79\xd0\xb9\x11\x80\x27\xf7\x97\xff\xdc\x1a\x43\xb9\xd0\xf1\x31\x82\x1b\x2e\x2c\x88\x73\xaf\x9c\x08\x64\x70\x33\x10\x86\x42\x02\x89\x18\x10\x02\xd5\x4e\x63\xa0\xa2\xdb\x04\xc2\x0a\x03\xe4\x68\x60\x49\x03\x95\x00\x0d\x53\x01\xc1\xa6\xcd\xe5\x27\x1a\x50\x86\x03\x9a\x9c\xc0\x96\x10\x56\xd4\x47\xca\x7c\xaa\x2d\x29\x3e\xc6\x8d\x1f\x70\x2a\xff\x0e\x3d\xd6\x14\xc8\x0f\x78\x04\x50\x81\xea\xa3\xc1\xd3\xc4\x27\xae\x4a\x6c\x74\x18\x9b\x1a\x10\xf9\xb2\x95\x04\x25\xd4\x75\xcb\x24\x32\x1b\x02\x55\x82\xb1\x3b\x64\x22\xe2\xbc\x5d\xab\x22\x06\xf7\xd8\xb3\xdc\x46\xba\x12\x7d\x43\xba\x9e\x20\xed\x18\xdd\x14\xf8\xfd\x0b\xe4\xe8\x42\xe3\x63\x0c\x68\x6b\x2a\xf2\xfb\xa2\xe2\xca\x95\xdd\x8c\x6a\x84\x88\x7a\x67\xe8\x46\x69\x0d\x4b\x8a\xc1\x71\x99\x0d\x8a\x9d\xc6\xad\x14\x83\x29\x36\xec\xa4\xbb\x30\x8d\x3b\xc3\x72\x0b\x77\xaf\xf9\x22\x00\x66\xda\xce\x57\x71\x4f\xc5\xd1\xfe\xfd\x6c\xe1\x95\x6d\x74\xd1\x23\x51\x26\x4a\xa4\xcc\x1a\x55\x0c\x50\x2a\x4d\x50\x5a\x0f\xf4\x88\xb5\xd3\x74\x2d\x11\x74\x1f\xa1\xc0\xcd\xfb\xbb\x77\x8b\x7b\xd1\x62\xa0\x91\x3a\x2a\x3f\x61\x8d\x5e\xe1\x52\x13\x28\x73\x42\x27\xc6\x8a\x03\x5a\x3d\x50\x06\x7f\x99\xf8\x96\x01\x80\x27\xa7\x55\x8e\x9c\x01\x7c\xfd\x0a\xf3\x0f\xe2\x3b\x9e\xc7\x5d\xe0\xdb\xb7\xab\x4b\x38\xf3\xf4\x4f\xa3\x3c\x15\xd9\xc8\xdc\x80\xb7\xdb\x4e\x34\xc2\x51\xa5\x22\x16\x53\x0f\x83\x48\x71\xaf\x11\x82\x1d\xd5\x09\x42\x70\x4a\x29\x71\x37\x6a\xdd\xd7\x1e\xbe\x06\xeb\x61\xb3\xa2\xb0\x22\xbf\x97\x9b\x12\x21\xdc\x94\xa5\x3a\x9f\x5f\x4b\x6b\x35\xe1\x18\xad\xc1\xab\xaa\x22\x3f\xc1\xcc\x45\x2b\x09\xaa\x20\x13\x5a\x33\xa3\x8d\x1a\x25\x1e\x30\x40\x45\x81\x81\x1e\x29\x6f\xa4\xdc\x6e\x56\x74\xca\x8d\x61\xa5\x78\x8f\x9b\x7c\x85\xa6\x12\xd2\x4c\x24\xed\x75\x67\x72\x57\x18\xe7\xfb\x45\xe9\xbe\x71\x05\x06\xba\x3f\xa1\x58\x95\x80\x2d\xa0\x8d\x0a\xab\x16\x95\x44\x1c\xd0\xa3\xe2\x20\x1c\x0a\x7d\x1b\xc5\x04\x2a\xfc\xc0\x70\x5f\x90\xd3\x76\x7b\x7f\x41\x56\x9d\xac\xdd\x69\x0a\xbd\xc7\xed\xc1\x8c\x00\x3b\xaa\xcf\x07\x14\x7f\x10\x09\xa8\xd1\x71\xb2\x23\xa2\x97\x63\x28\x9a\x35\x9f\x4d\x04\x11\x90\x1f\xce\x6f\xf5\x46\x71\x90\x2a\x28\x41\x16\xa5\x01\xd7\xa8\x74\x97\x7f\xad\x8b\x06\x2d\xd1\xfc\x7b\x9c\x45\xc3\x06\x64\x22\xdd\xe7\x8a\xd8\x13\x0b\x87\x4d\xca\x04\xfa\x2e\x74\x70\xaa\x88\xe7\x99\x5f\x24\x29\x39\x42\x11\x74\xe7\x08\x4f\x25\x79\x92\x54\x97\xb6\xe3\xcf\x17\x6f\xdf\xf4\xbd\x0b\x83\xb6\xb9\xf4\x30\x03\xb5\x90\x52\x66\x27\x58\x5a\x5d\x48\x81\x30\x05\xc8\x80\xdf\xa9\x2d\xa0\xf4\xb6\x6e\x7d\x3d\x39\x8e\x1a\x57\x79\x2c\x24\x28\x5e\x79\x5b\x9f\x35\xeb\xee\x40\x34\x9a\xc5\x31\xba\x06\x51\xc4\xbb\x0e\xac\xd5\x7e\x7c\x80\x04\xfb\x5f\xe2\xef\x02\xc7\x75\x8d\x7b\x36\x9b\x14\x58\xa3\x1b\x74\xbd\xfc\xec\xe9\x3c\xc8\xad\x29\xe2\x1d\x87\xcf\xf2\x79\xd3\x8b\x49\x3b\x1a\x50\x45\xe6\x08\x3e\xc9\x3d\x61\xa7\x04\x98\x42\xea\xb4\xd6\xe4\x55\xa9\xf2\x78\x89\x18\xa8\x86\xd4\xbc\xa6\x5e\x14\x76\x97\x95\x09\x9c\x9e\xcf\x69\x8d\x1c\x16\x1e\x0d\xab\x74\x4b\x1b\x93\x1a\xd8\xf7\xe6\x68\x51\x6a\x3d\x44\x1d\x04\x19\x90\xb7\x96\x58\xb0\xe5\xa8\x4a\x39\x4c\x68\x8f\x8d\xf6\x2c\x19\xef\x48\x4b\xeb\x6b\x0c\x19\xc8\x09\xf2\x4c\xf4\x5f\x52\x4d\x6a\x62\xc6\x6a\x4a\x3b\xf5\xb6\x95\x14\xab\x10\x56\x4d\x8d\x06\x3c\xb5\xc9\x04\xf4\x28\xe5\x1c\x53\x2b\xba\xb3\xf3\x12\x48\x76\xc9\xe4\xd7\x54\xfc\x4e\x46\x7c\x3b\xad\x55\x7e\x7f\xb4\x28\xd1\x9f\x6e\x7f\xf3\x6a\x37\x75\x96\xfd\x74\xab\x1d\xb8\x62\x83\x0c\xb9\xad\x5d\xec\x06\x4a\xeb\xcf\xfa\x44\x99\xf0\xcb\xcf\xa3\x12\x6d\xb6\x29\x13\xa8\xa2\x31\x1d\x9e\x90\x27\x59\x7c\x1b\x05\xc5\x4a\x84\x1b\xac\x49\xdf\x20\xd3\xae\x9b\xf1\xc9\x11\x39\x36\x4c\xe7\x2d\x8e\x11\xda\x05\x66\x1b\x71\x97\xf8\xed\xb8\x64\x9c\x40\xfe\x31\xa5\xc0\x21\xc3\xd7\x60\x8d\x00\x85\x85\x6f\xe8\x1a\x5e\xa1\x16\xdc\x1e\xee\xcc\x83\xb1\x1b\x73\x09\xa4\x38\xfd\x34\xa0\xc5\xd6\xd1\x11\x1c\x39\x8c\x7a\x5e\xaf\x81\xe6\xd5\x1c\x6e\x09\x8b\xed\x6c\x44\xdd\x79\x20\xa7\x9b\xef\x67\x23\xc5\x66\x44\xa8\x0d\x8a\x91\x89\x13\x59\xf6\x2c\xe2\xf9\x1e\x47\x09\x79\x6f\x8f\xaf\xed\x07\xec\xfd\x16\x45\x00\x7d\x5b\xe0\x9c\xb7\x4b\x4d\xb5\x9c\xdd\x8d\x29\xc6\x6a\xf9\x75\x6c\xfe\x8e\x51\xdb\x12\x94\x89\xdf\x8d\x8e\xca\x7a\xab\xde\xd8\xd0\x37\xe4\xd3\x0a\xfd\x19\xb7\x9c\xb2\x78\x83\xde\x28\x53\x9d\xb7\xf9\x8f\x4e\x68\x67\xb5\x0d\x72\x8d\x40\x3d\xc1\xfe\xff\x09\xfa\x88\x77\x07\x43\x1d\x99\x19\xa4\x8f\x96\xbb\x94\xc5\x3c\x27\x17\xa8\x78\x37\xfc\x8e\x78\x75\x75\xf0\xe9\x30\xbe\xf6\x39\xc2\x19\x7c\xfe\x22\xdf\x02\x83\xf5\x54\xa4\x8e\x28\x83\xcf\x5f\x66\xff\x0e\x00\xeb\x8f\x74\xdc\x39\x15\x00\x00")
 
 func configCrdsKudoDev_operatorversionsYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -136,12 +136,12 @@ func configCrdsKudoDev_operatorversionsYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "config/crds/kudo.dev_operatorversions.yaml", size: 3449, mode: os.FileMode(420), modTime: time.Unix(1576741322, 0)}
+	info := bindataFileInfo{name: "config/crds/kudo.dev_operatorversions.yaml", size: 5433, mode: os.FileMode(436), modTime: time.Unix(1792433638, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _configCrdsKudoDev_teststepsYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\x41\x6f\x1b\xb9\x0e\xbe\xfb\x57\x10\xee\x21\x2d\x10\x8f\x5f\xfb\x1e\x1e\x1e\xe6\x56\xa4\x6f\x17\xd9\x62\x93\xa2\xc9\xf6\x52\xf4\x40\x4b\xb4\x47\x6b\x8d\x34\x2b\x51\x76\xbc\x8b\xfd\xef\x0b\x4a\x33\x8e\x1d\x4f\x9a\x06\x28\x9a\x4b\x6b\x8d\x44\x7e\x24\x3f\x51\x1f\xb1\x33\x9f\x28\x44\xe3\x5d\x0d\xd8\x19\xba\x63\x72\xf2\x2b\x56\xeb\xff\xc5\xca\xf8\xf9\xe6\xf5\x82\x18\x5f\x4f\xd6\xc6\xe9\x1a\x2e\x52\x64\xdf\x7e\xa4\xe8\x53\x50\xf4\x8e\x96\xc6\x19\x36\xde\x4d\x5a\x62\xd4\xc8\x58\x4f\x00\x54\x20\x94\xc5\x5b\xd3\x52\x64\x6c\xbb\x1a\x5c\xb2\x76\x02\x60\x71\x41\x36\xca\x1e\x00\xe5\x1d\x07\x6f\x2d\x85\x19\x7b\x6f\x07\x87\x35\x4c\x5f\x57\xff\x9a\x4e\x00\x1c\xb6\x54\x03\x53\xe4\xc8\xd4\xc5\x6a\x9d\xb4\xaf\x34\x6d\x26\xb1\x23\x25\x36\x56\xc1\xa7\xae\x86\xfd\x7a\x39\xd2\x9b\x2f\x78\x6f\x29\xf2\x0d\x53\x97\x97\x3a\x9b\x02\xda\x03\x93\x13\x80\xa8\x7c\x47\x35\x5c\xc9\xc1\x0e\x15\xe9\x09\xc0\x06\xad\xd1\x39\x82\x62\xca\x77\xe4\xde\x7e\xb8\xfc\xf4\xef\x1b\xd5\x50\x8b\x65\x11\xa0\x0b\xbe\xa3\xc0\x66\xf0\x28\x7f\x07\xe9\xdc\xaf\x01\x68\x8a\x2a\x98\x2e\x5b\x84\x33\x31\x55\xf6\x80\x96\x04\x52\x04\x6e\x08\x36\x65\x8d\x34\xc4\xec\x06\xfc\x12\xb8\x31\x11\x02\x75\x81\x22\x39\xce\x90\x0e\xcc\x82\x6c\x41\x07\x7e\xf1\x3b\x29\xae\xe0\x86\x82\x18\x81\xd8\xf8\x64\xb5\x24\x78\x43\x81\x21\x90\xf2\x2b\x67\xfe\xdc\x5b\x8e\xc0\x3e\xbb\xb4\x28\xa9\x38\xb2\x68\x1c\x53\x70\x68\x25\x09\x89\xce\x01\x9d\x86\x16\x77\x10\x48\x7c\x40\x72\x07\xd6\xf2\x96\x58\xc1\xaf\x3e\x10\x18\xb7\xf4\x35\x34\xcc\x5d\xac\xe7\xf3\x95\xe1\x81\x40\xca\xb7\x6d\x72\x86\x77\xf3\x5c\x71\xb3\x48\xec\x43\x9c\x6b\xda\x90\x9d\x63\x67\x66\x19\xa7\xe3\x4c\xba\x56\xbf\x08\x3d\xb9\xe2\xd9\x01\x30\xde\x49\x95\x22\x07\xe3\x56\x93\xfb\xb4\x5a\x62\x7a\x34\xd1\xd7\x39\x2d\x39\xd8\xb2\x13\x90\x73\xd8\x0b\x5a\x19\xe7\x8c\x5b\x95\x14\x53\x26\x04\x08\x23\xaa\x03\x5b\x86\xa9\x8d\xf5\x51\x72\xc6\x2a\xfe\xb5\xba\x8f\x80\x7a\xfb\xe1\x72\xa8\xf4\xe0\x3d\xd0\x92\x02\x39\xae\x4e\x4e\x8e\x46\x5d\xfe\x96\x86\xac\xfe\x80\xdc\x3c\xe1\xef\xec\x72\x59\x1c\x88\x0d\x49\x05\x42\x67\x48\xd1\x11\x75\xc0\xb8\xc8\x84\xba\x5f\x94\x62\x04\x3a\xb1\x0b\xfd\xee\xf3\x42\xcb\x02\xeb\x80\x6c\x8c\xc6\x01\x96\xdb\x03\xbf\xdc\x5c\x5f\xcd\x7f\xf6\x05\x27\xa0\x52\x14\xe3\x88\xc5\xc8\xc8\xd4\x92\xe3\x73\x88\x49\x35\x80\x51\xe0\x9b\x40\xfa\x46\xbe\x54\x2d\x3a\xb3\xa4\xc8\x55\x6f\x9f\x42\xfc\xfc\xe6\x4b\x05\x3f\xf9\x00\x74\x87\x6d\x67\xe9\x7c\xc4\xac\x29\x99\xed\xa3\xeb\x13\xac\x08\x4c\x2c\x29\xd8\x5b\x83\xad\xe1\x26\xc3\xee\xbc\xee\x03\xdb\x4a\x40\x23\x46\x19\xd7\x04\xbe\x0f\x31\x11\x58\xb3\xa6\x1a\xa6\xd2\x8d\x0e\xe0\xfd\x25\x3d\xe8\xef\x29\xbc\xdc\x36\x14\x08\xa6\xf2\x73\x5a\x20\x8c\x25\xa0\xbf\x89\xb2\x6b\xe0\xc3\x3d\x38\x6e\x84\xb1\xc1\xac\x56\x14\x48\xe7\x8f\x24\x57\xe5\x15\xf8\x20\x31\x3a\x3f\x62\xf1\xfe\x78\x36\x2a\x95\xea\x48\x99\xa5\x21\x7d\x02\xf6\xf3\x9b\x2f\x53\x78\x79\x9c\x8d\xb1\x74\x3a\x4d\x77\xf0\x06\x8c\x2b\x19\xea\xbc\x7e\x55\xc1\x6d\x66\xc1\xce\x31\xde\x89\x17\xd5\xf8\x48\x0e\xbc\xb3\x3b\x89\xaa\xc1\x0d\x41\xf4\x2d\xc1\x96\xac\x9d\x95\x4e\x37\x96\xd6\x2d\xee\x24\xf2\xa1\x48\xc2\x29\x84\x0e\x03\x3f\xe8\x6e\xb7\xd7\xef\xae\xeb\xe2\x5f\x28\xb2\x72\xe2\xd4\x79\x1e\x31\xb9\x34\xd2\xc1\xa4\x75\xe5\xed\x85\x83\x02\x36\x15\x42\xb0\x07\xd5\xa0\x5b\x51\x89\x88\x60\x99\x38\x05\xaa\xce\x9e\x73\x03\xf3\x03\xf3\xc4\xe5\x7b\x6f\x9c\x3e\xb9\xe6\xdf\xb9\x5f\x0a\xc4\x38\x13\x34\xf1\x59\xf8\xf3\xf3\xfa\x04\xfe\xab\x03\x5a\x7e\x15\xff\x3a\x2d\x28\x38\x62\xca\x21\x68\xaf\xa2\xa0\x57\xd4\x71\x9c\xfb\x0d\x85\x8d\xa1\xed\x7c\xeb\xc3\xda\xb8\xd5\x4c\x58\x36\x2b\x65\x8d\xf3\xfc\x64\xcf\x5f\xe4\x7f\x9e\x8d\x3f\x3f\xd9\xdf\x12\x44\xde\xf8\x23\x22\x11\x3f\x71\xfe\xac\x40\x86\x07\xef\xdb\x1e\x90\xb3\x9b\x72\x9b\xd5\xc3\x73\xc2\xea\x6d\x63\x54\x33\xa8\x86\xfb\xa6\x37\x72\x45\x5a\xd4\x74\x2e\x3d\x04\xdd\xee\x7b\x33\x52\xf2\x95\x82\x38\xdf\xcd\x7a\x95\x37\x43\xa7\xe5\xff\xd1\x44\x96\xf5\x67\x25\x28\x99\x27\x2f\xda\x6f\x97\xef\x7e\x0c\x4f\x93\x79\xd6\x35\x2b\x1f\x8a\x8d\x13\x39\x83\x21\xe0\x6e\xbf\x9a\x9b\xec\x61\x9c\x4b\x1f\x5a\xe4\x5a\x24\xd9\x7f\xff\x73\x72\x58\x84\xda\x8a\xc2\x7e\xfd\x61\x3b\x1a\x69\x44\x26\x02\x0e\xcf\x76\x79\xc2\xf6\xd2\x32\x8b\x83\x86\xe0\xe3\xff\x6f\x6e\xf7\xc4\xca\x44\x3a\xd6\x9b\xc3\x83\xda\x1f\x8b\xf7\xa2\x53\x44\xa2\x71\xcb\xfc\x6a\x49\xcf\x0d\xbe\x2d\x0f\x96\xd3\x9d\x37\xae\x28\x2f\x65\x0d\xb9\xe3\x8e\x1d\xd3\xa2\x35\x2c\x7c\xfd\x23\x89\x2e\x07\xf6\x15\x5c\xa0\x73\x9e\x61\x41\x90\x3a\x8d\x4c\xba\x82\x4b\x07\x17\xd8\x92\xbd\xc0\x48\x3f\xa8\x85\x8e\x56\x55\x18\xa4\xd8\x3e\x9a\xea\xf7\xe5\x3b\x08\x06\x74\x3a\x0b\x8e\x90\xdc\xa0\x3d\x23\xf7\x2f\xdb\xa0\x3b\xbf\xae\x38\x1f\x21\xd6\x18\x7f\x0e\x27\xb0\x51\x64\xd7\x1b\x51\x81\x9a\xb2\x6b\x4d\x4b\x4c\x96\xf7\xa7\xa4\x90\xdc\xcf\x67\xa2\x2e\xfc\xe1\xe6\x93\x29\x41\xb4\x72\x6e\xc0\xd5\x09\xa8\x07\x5c\x97\x32\xc8\x10\xf6\x28\xaa\x4b\xa7\x8d\x92\x39\xa4\x57\x3b\x42\x9e\x4c\x54\x39\x59\x94\xf9\x0c\x22\x2e\x69\x48\xa4\xdc\x4a\x9f\x18\xf0\x08\x51\x20\xb4\x39\xf7\xe5\x76\x83\xb2\x29\x32\x85\x53\x7c\x0b\xef\x2d\xe1\x30\x46\x09\xeb\x44\x6d\x0e\xf0\x66\x7b\xc0\xfb\x85\xbe\xe0\x32\x17\x0e\x83\xf2\x30\x13\x8b\x7a\x4d\xb9\x62\x22\x70\x3b\x26\x7d\xf5\x70\x00\x9d\x4e\x8f\x46\xcf\xfc\x53\x79\xa7\xf3\xd4\x1c\x6b\xf8\xfc\x45\x86\x50\xf6\x81\x74\xdf\xc7\xcb\xe2\x3f\x01\x00\x00\xff\xff\xdf\xa0\xe3\xc3\x98\x0f\x00\x00")
+var _configCrdsKudoDev_teststepsYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\x51\x6f\xdb\x48\x0e\x7e\xf7\xaf\x20\xdc\x87\xb4\x40\x2c\x5f\x7b\x87\xc3\x41\x6f\x45\x7a\xbb\xc8\x16\x9b\x14\x4d\xb6\x2f\x41\x1e\xc6\x1a\xda\xe2\x46\xe2\x68\x87\x94\x1d\xef\x62\xff\xfb\x82\x23\xc9\x51\x6a\x3b\x69\x80\xa2\x68\x81\x58\xd4\x0c\xf9\x91\xfc\x48\x91\xae\xa1\x2f\x18\x85\x02\xe7\xe0\x1a\xc2\x7b\x45\xb6\x27\xc9\xee\xfe\x27\x19\x85\xf9\xfa\xed\x02\xd5\xbd\x9d\xdc\x11\xfb\x1c\xce\x5a\xd1\x50\x7f\x46\x09\x6d\x2c\xf0\x03\x2e\x89\x49\x29\xf0\xa4\x46\x75\xde\xa9\xcb\x27\x00\x45\x44\x67\xc2\x6b\xaa\x51\xd4\xd5\x4d\x0e\xdc\x56\xd5\x04\xa0\x72\x0b\xac\xc4\xce\x00\x14\x81\x35\x86\xaa\xc2\x38\xd3\x10\xaa\xc1\x60\x0e\xd3\xb7\xd9\xbf\xa6\x13\x00\x76\x35\xe6\xa0\x28\x2a\x8a\x8d\x64\x77\xad\x0f\x99\xc7\xf5\x44\x1a\x2c\x4c\xc7\x2a\x86\xb6\xc9\x61\x27\xef\xae\xf4\xea\x3b\xbc\xd7\x28\x7a\xa5\xd8\x24\x51\x53\xb5\xd1\x55\x23\x95\x13\x00\x29\x42\x83\x39\x5c\xb8\x1a\xa5\x71\x05\xfa\x09\xc0\xda\x55\xe4\x93\x07\x9d\xaa\xd0\x20\xbf\xff\x74\xfe\xe5\xdf\x57\x45\x89\x75\x72\xd1\xc4\x4d\x0c\x0d\x46\xa5\xc1\xa2\xfd\x1b\x85\x73\x27\x03\xf0\x28\x45\xa4\x26\x69\x84\x13\x53\xd5\x9d\x01\x6f\x01\x44\x01\x2d\x11\xd6\x9d\x0c\x3d\x48\x32\x03\x61\x09\x5a\x92\x40\xc4\x26\xa2\x20\x6b\x82\x34\x52\x0b\x76\xc4\x31\x84\xc5\xef\x58\x68\x06\x57\x18\x4d\x09\x48\x19\xda\xca\x5b\x80\xd7\x18\x15\x22\x16\x61\xc5\xf4\xe7\x4e\xb3\x80\x86\x64\xb2\x72\x16\x8a\x47\x1a\x89\x15\x23\xbb\xca\x82\xd0\xe2\x29\x38\xf6\x50\xbb\x2d\x44\x34\x1b\xd0\xf2\x48\x5b\x3a\x22\x19\xfc\x1a\x22\x02\xf1\x32\xe4\x50\xaa\x36\x92\xcf\xe7\x2b\xd2\x81\x40\x45\xa8\xeb\x96\x49\xb7\xf3\x94\x71\x5a\xb4\x1a\xa2\xcc\x3d\xae\xb1\x9a\xbb\x86\x66\x09\x27\x9b\x6f\x92\xd5\xfe\x55\xec\xc9\x25\x27\x23\x60\xba\xb5\x2c\x89\x46\xe2\xd5\x4e\xec\xb1\x42\xc5\xa3\x81\xbe\x4c\x61\x49\xce\x76\x27\xc1\x69\x72\x7b\x81\x2b\x62\x26\x5e\x59\xfc\x4c\x60\x51\x00\x63\x44\x36\xd2\x45\x8a\xf5\x28\xb3\xc7\x32\xfe\x54\xde\x0f\x80\x7a\xff\xe9\x7c\xc8\xf4\x60\x3d\xe2\x12\x23\xb2\x8e\x8d\x3f\xe1\x75\xf7\x7f\x49\x58\xf9\x4f\x4e\xcb\x67\xec\x9d\x9c\x2f\x3b\x03\xa6\xc3\x42\xe1\xa0\x21\x2c\xf0\x11\x75\x80\x58\x14\x9d\xef\x85\xc8\x4a\x11\xf7\xf4\x42\x7f\xfa\xb4\xa3\x65\x07\x6b\x44\x36\x75\xc4\xe0\x8c\x38\xe4\xe1\x97\xab\xcb\x8b\xf9\xcf\xa1\xc3\x09\xae\x28\x50\xe4\x80\x46\x51\xa7\x58\x23\xeb\x29\x48\x5b\x94\xe0\xc4\x8a\x85\x22\xfa\x2b\x7b\x93\xd5\x8e\x69\x89\xa2\x59\xaf\x1f\xa3\xdc\xbc\xbb\xcd\xe0\xa7\x10\x01\xef\x5d\xdd\x54\x78\x7a\x40\x2d\x75\x91\xed\xbd\xeb\x03\x5c\x20\x50\x62\x83\x1b\xd0\x62\x84\x0d\x69\x99\x60\x37\xc1\xf7\x8e\x6d\xac\x7a\x0e\x28\x55\x77\x87\x10\x7a\x17\x5b\x84\x8a\xee\x30\x87\xa9\x75\xa3\x11\xbc\xbf\xac\x07\xfd\x3d\x85\xd7\x9b\x12\x23\xc2\xd4\x1e\xa7\x5d\x0a\x0e\x05\xa0\xaf\x44\x3b\x35\xf0\xe1\x01\x9c\x96\xc6\xd8\x48\xab\x15\x46\xf4\xe9\x20\xae\x91\xf5\x0d\x84\x08\xb4\x04\x0e\x07\x34\x3e\x5c\x4f\x4a\x49\xc0\x10\xd2\x92\xd0\xef\x81\xbd\x79\x77\x3b\x85\xd7\x0f\x37\x2c\x1a\x07\x54\x12\x7b\xbc\x87\x77\x40\xdc\x45\xa8\x09\xfe\x4d\x06\xd7\xf6\x53\xb6\xac\xee\xde\x02\x5b\x94\x41\x90\x21\x70\xb5\xb5\x20\x97\x6e\x8d\x20\xa1\x46\xd8\x60\x55\xcd\xba\x4e\x77\x28\xac\x1b\xb7\x35\xcf\x87\x24\x19\x4d\x1d\x34\x2e\xea\x23\x8a\x66\x70\x7d\xf9\xe1\x32\xef\xec\x1b\x45\x56\x6c\x46\x39\x3c\x6e\x5f\x43\x71\x58\x07\xb3\xd6\x95\x8e\x77\x1c\x34\xb0\x6d\xd2\x65\xf0\x8a\xd2\xf1\xca\xba\x56\x8a\xea\xb2\xd5\x36\x62\x76\x32\xd9\x4b\xcf\xf1\x0a\x4c\x1f\x98\x67\x8a\xef\x23\xb1\xdf\x2b\xf3\xef\xdc\x2f\xad\x49\xc8\xcc\xd0\xc8\x8b\xf0\x1b\x3b\x9e\x6b\x1e\x17\x23\x5a\x3e\x89\xff\xae\x5d\x60\x64\x54\x4c\x2e\xf8\x50\x88\xa1\x2f\xb0\x51\x99\x87\x35\xc6\x35\xe1\x66\xbe\x09\xf1\x8e\x78\x35\x33\x96\xcd\xba\xda\x94\xb9\xc1\x90\xf9\xab\xf4\xe7\xc5\xf8\xd3\x27\xfb\x5b\x9c\x48\x07\x7f\x84\x27\x66\x47\xe6\x2f\x72\x64\xf8\xe0\x7d\xdb\x07\xe4\xe4\xaa\xab\xe6\xe2\xeb\x7b\xc6\xea\x4d\x49\x45\x39\x4c\x0d\x0f\x4d\x6f\x4f\x23\x40\xed\x3c\x9e\x5a\x0f\x71\xbc\xfd\xde\x8c\xb4\x78\xb5\xd1\x8c\x6f\x67\xfd\x94\x37\x73\xec\xed\xb7\x90\xa8\xc9\x5f\x14\xa0\x96\x9e\x2d\xb4\xdf\xce\x3f\xfc\x18\x9e\xb6\xf4\xa2\x32\xeb\x5e\x74\x5c\x1f\xbd\xe8\xc4\x2e\x46\xb7\xdd\x49\x53\x93\x1d\xfb\xb9\x0c\xb1\x76\x9a\x03\xb1\xfe\xf7\x3f\x23\x79\x77\xd9\x06\xb5\x15\xc6\xc9\xb1\x76\xf4\x38\x3e\xa9\x11\x91\x80\xeb\x41\xf6\x9f\xb0\xdd\x68\x69\x22\x0b\xde\xe7\xff\x5f\x5d\xef\x88\x95\x88\x34\xd9\x1f\x00\x1e\x26\x52\x79\x18\x3a\x6d\x48\x24\x5e\x62\xec\x7b\x6e\x0c\x75\x6a\x7b\xc8\xbe\x09\xc4\xdd\xe4\x55\x54\x84\xfc\xb8\x63\x4b\xbb\xa8\x49\x8d\xaf\x7f\xb4\x36\xea\x83\x86\x0c\xce\x1c\x73\x50\x58\x20\xb4\x8d\x77\x8a\x3e\x83\x73\x86\x33\x57\x63\x75\xe6\x04\x7f\x50\x0b\x3d\x98\x55\xeb\x74\x85\x56\x47\x43\xfd\xb1\x7b\x0f\x86\xc1\xb1\x4f\x03\x47\x6c\x79\x98\x3d\x45\xfb\x2f\xdb\x30\x77\x3e\x3d\x71\x1e\x21\xd6\x21\xfe\x8c\x37\xb0\x83\xc8\x2e\xd7\x18\x23\x79\x4c\x99\xf0\xb8\x74\x6d\xa5\xbb\x5b\x96\x48\xed\xf7\x33\x9b\x2e\xc2\xf8\xf0\xde\x96\x60\xb3\x72\x6a\xc0\xd9\xe4\x19\xae\x5b\x1a\x6c\x09\x3b\x8a\xea\x9c\x3d\x15\xb6\x87\xf4\xd3\x8e\xf5\xae\x44\x54\xbb\x99\x56\x35\x98\x81\xb8\x25\x0e\x81\xb4\xaa\x0c\xad\x82\x1b\x69\x04\x88\xe8\x2a\xf8\xb8\xab\x6e\x28\xaa\x56\x14\xe3\x3e\xbe\x45\x08\x15\x3a\xee\xe5\xc6\x3a\x9b\x36\x07\x78\xb3\x21\xbf\xbb\xe7\xc1\x01\xdb\x0b\xfb\x06\x0d\xc3\x4e\x6c\xd3\x6b\x9b\x76\x04\x1b\x70\x1b\x45\x7f\xf1\xf5\x02\x3a\x9d\x3e\x5a\x3d\xd3\x63\x11\xd8\xa7\xad\x59\x72\xb8\xb9\xb5\x25\x54\x43\x44\xdf\xf7\x71\xc9\xe1\xe6\x76\xf2\xcf\x00\x87\xb0\xbe\xfb\x98\x0f\x00\x00")
 
 func configCrdsKudoDev_teststepsYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -156,12 +156,12 @@ func configCrdsKudoDev_teststepsYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "config/crds/kudo.dev_teststeps.yaml", size: 3992, mode: os.FileMode(420), modTime: time.Unix(1576740834, 0)}
+	info := bindataFileInfo{name: "config/crds/kudo.dev_teststeps.yaml", size: 3992, mode: os.FileMode(436), modTime: time.Unix(1576882156, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _configCrdsKudoDev_testsuitesYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x57\x51\x6f\x1b\x37\x0c\x7e\xf7\xaf\x20\xb2\x87\xa6\x40\xe3\xb4\xe8\x30\x14\xf7\x56\xd8\x7b\x08\x8a\x35\x41\x93\x76\x0f\xc3\x1e\x64\x89\x67\x73\xd1\x49\x37\x8a\xf2\x9a\xfd\xfa\x81\x3a\xdf\xf9\x2e\xb6\x9b\xb6\x6b\x9e\x72\xb4\x44\x7e\xfa\xf8\x91\x94\x4c\x4b\x9f\x90\x13\xc5\x50\x81\x69\x09\x3f\x0b\x06\xfd\x4a\xf3\xfb\x37\x69\x4e\xf1\x72\xfb\x6a\x85\x62\x5e\xcd\xee\x29\xb8\x0a\x16\x39\x49\x6c\x3e\x60\x8a\x99\x2d\x2e\xb1\xa6\x40\x42\x31\xcc\x1a\x14\xe3\x8c\x98\x6a\x06\x60\x19\x8d\x1a\xef\xa8\xc1\x24\xa6\x69\x2b\x08\xd9\xfb\x19\x80\x37\x2b\xf4\x49\xd7\x00\xd8\x18\x84\xa3\xf7\xc8\x17\x12\xa3\xef\x03\x56\x70\xf6\x6a\xfe\xf2\x6c\x06\x10\x4c\x83\x15\x08\x26\x49\x99\x04\xd3\xfc\x3e\xbb\x38\x77\xb8\x9d\xa5\x16\xad\x3a\x59\x73\xcc\x6d\x05\x83\xbd\xdb\xb3\xf3\xdf\x01\xbe\xc3\x24\xb7\xba\xbd\xd8\x5a\x9f\xd9\xf8\xb1\xd3\x19\x40\xb2\xb1\xc5\x0a\xde\xeb\xd6\xd6\x58\x74\x33\x80\xad\xf1\xe4\xca\x21\x3a\x67\xb1\xc5\xf0\xf6\xe6\xea\xd3\xeb\x5b\xbb\xc1\xc6\x74\x46\x80\x96\x63\x8b\x2c\xd4\xc7\xd4\xbf\x11\xa3\x83\x0d\xc0\x61\xb2\x4c\x6d\xf1\x08\xcf\xd4\x55\xb7\x06\x9c\x72\x88\x09\x64\x83\xb0\xed\x6c\xe8\x20\x95\x30\x10\x6b\x90\x0d\x25\x60\x6c\x19\x13\x06\x29\x90\x46\x6e\x41\x97\x98\x00\x71\xf5\x17\x5a\x99\xc3\x2d\xb2\x3a\x81\xb4\x89\xd9\x3b\xe5\x78\x8b\x2c\xc0\x68\xe3\x3a\xd0\xbf\x83\xe7\x04\x12\x4b\x48\x6f\x94\x8b\x89\x47\x0a\x82\x1c\x8c\x57\x12\x32\xbe\x00\x13\x1c\x34\xe6\x01\x18\x35\x06\xe4\x30\xf2\x56\x96\xa4\x39\xfc\x16\x19\x81\x42\x1d\x2b\xd8\x88\xb4\xa9\xba\xbc\x5c\x93\xf4\x1a\xb2\xb1\x69\x72\x20\x79\xb8\x2c\x49\xa7\x55\x96\xc8\xe9\xd2\xe1\x16\xfd\xa5\x69\xe9\xa2\xe0\x0c\x52\x74\xd7\xb8\x9f\x78\xa7\xaf\xf4\x6c\x04\x4c\x1e\x34\x4b\x49\x98\xc2\x7a\x4f\x36\x0b\xd5\xc6\x4a\x5a\x12\x9f\xa4\xfb\x6e\x83\xe0\x88\xd1\x4a\xe4\x07\x3d\x78\xcc\xd2\x66\xd9\x6f\x56\xdb\xb9\xcd\xcc\x18\x04\xfe\x89\x7c\x4f\x61\xbd\xdf\x31\x25\xa7\x86\x10\x05\x54\x81\x54\x13\xba\xe7\xf3\xa7\x20\x5a\x76\x5f\x02\x77\x63\x64\xa3\xf1\x17\x1f\x96\x05\x07\x85\x24\xc6\x7b\x58\x61\xad\x9c\x72\x0e\x41\xd1\x14\xc5\x3e\x19\xab\x68\xfe\xa4\xea\xde\x51\x70\x40\x09\xcc\x6e\x5b\x97\xbd\xbd\xb8\x4a\x9c\x0d\xc2\x87\x5f\x6f\xef\xa0\xcf\x41\x11\xe0\x54\x71\x45\x6b\xfb\x6d\x69\x2f\x3b\x95\x09\x85\x1a\xb9\x93\x6d\xcd\xb1\x29\x1e\x31\xb8\x36\x52\x90\xf2\x61\x3d\x61\x98\x4a\x2e\xe5\x55\x43\xa2\x3a\xff\x3b\xeb\x41\x41\xe2\x1c\x16\x26\x28\xd5\x2b\x84\xdc\x3a\x23\xe8\xe6\x70\x15\x60\x61\x1a\xf4\x0b\x93\xf0\x47\x8b\x4e\x09\x4d\x17\xca\xe0\xd3\xb2\xd3\x55\x8b\x18\x6a\x5a\x3f\x99\x57\x3d\xf1\xbb\xab\xf7\x4b\xad\xc5\x9a\xd6\x99\x4b\x09\x43\x4d\x1e\xf5\xe7\x9c\xf0\xab\xb2\xba\x88\x41\xf0\xb3\x9c\x0c\xd7\x87\xd0\x45\x5f\xed\x37\xaf\xd0\x8a\x3f\xed\xb3\xfb\x1d\x94\x49\x13\x5c\x91\x27\xe7\xf0\x58\x9a\x26\x3c\x1c\xca\x93\x04\x9b\x54\x4d\x92\x7c\x14\x43\x6f\x36\xcc\x66\x5f\x69\x8d\x09\x54\x63\x92\x25\x71\xfa\x22\xc3\x05\x53\x5f\xa9\x84\xa9\x50\x60\xa8\xe0\xea\x9d\x1c\x29\xab\x09\xae\x93\x25\xf6\x3f\xcf\x30\x9a\x88\x47\xf1\xdf\xa2\xec\x06\x22\x44\x2e\x3a\x51\x04\x50\xe6\x52\x19\x64\x87\x09\xec\x4a\x6f\x30\xb7\x86\x8d\xf7\x78\x3a\x83\xcf\xb4\xf5\x35\xe6\x33\x35\xb9\x81\x90\x9b\x15\x72\x99\x29\x3d\x2b\x9a\x4d\x23\x10\x83\x45\x38\x77\x58\x9b\xec\xa5\x82\x37\xcf\xe7\xe3\x0a\xa8\x23\x37\x46\x2a\x9d\x0b\xbf\xfc\x7c\x00\x49\xa7\xc5\x1a\x79\xb0\xa7\x7b\x6a\x17\x3e\x27\x41\x5e\xa2\x47\xc1\x93\xe0\xae\x6a\x48\x28\x2f\xc0\xc5\xd2\x52\x5d\x59\x5d\x78\x68\xa2\xbd\x47\xd7\xdf\x10\xa0\xf5\x26\xa0\x72\xa4\x85\x00\xb6\x73\x7e\x48\xce\x2a\x46\x8f\x26\x4c\x90\x7c\x37\x84\x61\x04\x81\xa9\x05\x79\x2f\x92\x5d\x9a\xa6\x2d\xf1\x9c\x9a\xd6\xab\xfc\x6e\x1f\x9f\xfe\xc8\x7c\x38\xc0\x29\x86\x65\xd1\x9d\xf5\x46\x8f\x7a\x12\xee\xef\x1b\x94\x8d\xa6\x90\x0b\x5c\x89\xdd\x5e\x30\xe0\xa3\x35\x1e\x50\xac\x2b\xe3\x5a\x2b\x9b\x03\x0a\x26\x78\x7b\x73\x05\xa9\xb4\xe7\x09\xe2\x7a\x24\xb9\x23\x73\xe5\x28\x46\xed\x31\xdf\x8d\x6d\x9c\xbb\xef\x8b\xfe\x71\x79\xfd\xcd\xd1\x4b\xf7\xfd\xb8\xbc\x1e\xdd\x36\xbf\x35\xb8\x3c\xd5\x87\x96\xc7\xdb\x4f\x29\x66\x6b\x12\xf6\x95\xf6\xe3\x5a\x8b\x50\x83\x31\x9f\x1e\x06\xd7\x5b\x64\x26\xd7\x29\x79\x57\xd6\xfd\x26\xad\xff\xd7\x2f\x21\xa1\x8d\xda\xd2\xcf\x29\xf4\xff\x4f\xa4\xfa\x2d\x45\xaf\x53\x9b\x18\x87\x9b\xc7\xc5\xee\xce\x33\x7c\x8e\xdb\xf9\x60\x94\xc7\x86\x83\x3a\x98\xfe\xa2\xea\x1b\x2c\xfb\x01\xfc\xd8\xa4\x03\xf0\xd1\xc6\x8f\xcb\xeb\xbd\x65\x68\x0a\x13\xd3\xa4\x66\xf7\x10\x3b\xca\x86\xef\xbe\xdf\x0e\x86\xf1\xf5\x73\x0f\xa4\x1b\x9b\xfa\x8a\xe8\x5f\x56\xfd\x23\x2a\x89\x91\x5c\xf2\x6e\xac\xc5\x56\xd0\xbd\x7f\xfc\x60\x39\x3b\x9b\xbc\x54\xca\xa7\xa6\xa7\x3c\xb3\x52\x05\x7f\xfc\xa9\x4f\x16\x89\x8c\x6e\xf7\x84\xe8\x8c\xff\x05\x00\x00\xff\xff\xb7\xfa\x4b\x7b\xc9\x0d\x00\x00")
+var _configCrdsKudoDev_testsuitesYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x57\x41\x6f\x1b\x39\x0f\xbd\xfb\x57\x10\xf9\x0e\x49\x80\x66\xd2\xa2\x1f\x16\xc5\xdc\x0a\x7b\x0f\x41\xb1\x4d\xd0\xa4\xdd\x43\xd1\x83\x2c\x71\x6c\xae\x35\xd2\x2c\x45\x79\x9b\xfd\xf5\x0b\x6a\x3c\xe3\x89\x5b\x6f\xd2\x6c\x91\x4b\xe6\x59\x22\x1f\xc9\x47\x51\x32\x1d\x7d\x42\x4e\x14\x43\x0d\xa6\x23\xfc\x2a\x18\xf4\x2b\x55\x9b\x37\xa9\xa2\x78\xb9\x7d\xb5\x44\x31\xaf\x66\x1b\x0a\xae\x86\x79\x4e\x12\xdb\x0f\x98\x62\x66\x8b\x0b\x6c\x28\x90\x50\x0c\xb3\x16\xc5\x38\x23\xa6\x9e\x01\x58\x46\xa3\xe0\x1d\xb5\x98\xc4\xb4\x5d\x0d\x21\x7b\x3f\x03\xf0\x66\x89\x3e\xe9\x1a\x00\x1b\x83\x70\xf4\x1e\xf9\x42\x62\xf4\x83\xc3\x1a\x4e\x5e\x55\x2f\x4f\x66\x00\xc1\xb4\x58\x83\x60\x92\x94\x49\x30\x55\x9b\xec\x62\xe5\x70\x3b\x4b\x1d\x5a\x35\xb2\xe2\x98\xbb\x1a\x46\xbc\xdf\xb3\xb3\xdf\x13\xbe\xc3\x24\xb7\xba\xbd\x60\x9d\xcf\x6c\xfc\xd4\xe8\x0c\x20\xd9\xd8\x61\x0d\xef\x4d\x8b\xa9\x33\x16\xdd\x0c\x60\x6b\x3c\xb9\x12\x44\x6f\x2c\x76\x18\xde\xde\x5c\x7d\x7a\x7d\x6b\xd7\xd8\x96\x28\x15\xee\x38\x76\xc8\x42\x83\x4f\xfd\x9b\x64\x74\xc4\x00\x1c\x26\xcb\xd4\x15\x8b\x70\xaa\xa6\xfa\x35\xe0\x34\x87\x98\x40\xd6\x08\xdb\x1e\x43\x07\xa9\xb8\x81\xd8\x80\xac\x29\x01\x63\xc7\x98\x30\x48\xa1\x34\x31\x0b\xba\xc4\x04\x88\xcb\x3f\xd0\x4a\x05\xb7\xc8\x6a\x04\xd2\x3a\x66\xef\x34\xc7\x5b\x64\x01\x46\x1b\x57\x81\xfe\x1e\x2d\x27\x90\x58\x5c\x7a\xa3\xb9\x78\x60\x91\x82\x20\x07\xe3\x35\x09\x19\x5f\x80\x09\x0e\x5a\x73\x0f\x8c\xea\x03\x72\x98\x58\x2b\x4b\x52\x05\xbf\x45\x46\xa0\xd0\xc4\x1a\xd6\x22\x5d\xaa\x2f\x2f\x57\x24\x83\x86\x6c\x6c\xdb\x1c\x48\xee\x2f\x4b\xd1\x69\x99\x25\x72\xba\x74\xb8\x45\x7f\x69\x3a\xba\x28\x3c\x83\xc6\x96\xaa\xd6\xfd\x8f\x77\xfa\x4a\xa7\x13\x62\x72\xaf\x55\x4a\xc2\x14\x56\x23\x6c\x58\xa8\x31\x56\xd2\x82\xf8\x68\xba\xef\xd6\x08\x8e\x18\xad\x44\xbe\xd7\xc0\x63\x96\x2e\xcb\x7e\xb3\x62\x67\x36\x33\x63\x10\xf8\x2b\xf2\x86\xc2\x6a\xbf\x63\x62\x16\x80\x1a\x08\x51\x40\x15\x48\x0d\xa1\x3b\xaf\x1e\xa3\x68\xd9\xfd\x1b\xb9\x1b\x23\x6b\xf5\x3f\xff\xb0\x28\x45\xa1\x90\xc4\x78\x0f\x4b\x6c\x34\xa7\x9c\x43\x50\x36\x5a\xa5\xf4\xa8\xaf\xa2\xf9\x63\x9e\x4e\xdf\x51\x70\x40\x09\xcc\x6e\x5b\x5f\xe0\xbd\xb8\x14\x52\x4d\x7c\xf8\xf5\xf6\x0e\x86\x1a\x14\x01\x4e\x4c\xc2\x4e\x6b\xfb\x6d\x69\x2f\x3b\x95\x09\x85\x06\xb9\xec\x82\x86\x63\x5b\x54\x86\xc1\x75\x91\x82\x94\x0f\xeb\x09\xc3\x43\xc9\xa5\xbc\x6c\x49\x54\xe7\x7f\x66\x0d\x14\x24\x56\x30\x37\x41\x53\xbd\x44\xc8\x9d\x33\x82\xae\x82\xab\x00\x73\xd3\xa2\x9f\x9b\x84\x3f\x5b\x74\x9a\xd0\x74\xa1\x19\x7c\x5c\x76\xba\x6a\x1e\x43\x43\xab\x47\xeb\xaa\x11\xbf\xbb\x7a\xbf\xd0\x5e\x6c\x68\x95\xb9\xb4\x30\x34\xe4\x51\xcb\x9d\x13\x3e\xa9\xaa\xf3\x18\x04\xbf\xca\x51\x77\x83\x0b\x5d\xf4\x64\xbb\x79\x89\x56\xfc\x71\x9b\xfd\xef\xa0\x99\x34\xc1\x15\x79\x72\x0e\x87\xd2\x34\xe1\xfe\x5b\x79\x92\x60\x3b\x39\x14\x8f\x72\x18\x60\xc3\x6c\xf6\x9d\xd6\x9a\x40\x0d\x26\x59\x10\xa7\xa3\xf4\xb4\x73\x0a\xa7\xa1\x53\x09\x53\x49\x81\xa1\xd2\x32\x83\x91\xef\xb4\xd5\xc4\x24\x1c\x6f\xb1\xff\x18\xc3\x64\x22\x7e\x97\xff\x2d\xca\x6e\x20\x42\xd4\x86\xc1\xc2\x00\xca\x5c\x2a\x83\x6c\xca\xa5\xf7\xd0\xb7\xde\x08\x77\x86\x8d\xf7\x78\xbc\x82\xa7\x7a\xf4\xb5\xe6\x2b\xb5\xb9\x85\x90\xdb\x25\xb2\x0e\x0c\x19\xb2\xa2\xd5\x34\x02\x31\x58\x84\x33\x87\x8d\xc9\x5e\x6a\x78\x73\x5e\x4d\x3b\xa0\x89\xdc\x1a\xa9\x81\x82\xfc\xf2\xff\x09\xde\x53\xd2\x69\xb1\x42\x1e\xf1\xb4\xa1\x6e\xee\x73\x12\xe4\x05\x7a\x14\x3c\x4a\xee\xaa\x81\x84\xf2\x02\x5c\x2c\x47\xaa\x2b\xab\x4b\x1e\xda\x68\x37\xe8\x86\x1b\x02\x74\xde\x04\xd4\x1c\x69\xdb\x81\xed\x8d\x7f\x9b\x9c\x65\x8c\x1e\x4d\x18\x71\x65\xf2\x6c\x0a\xe3\x08\x02\xd3\x08\xf2\x5e\x24\xbb\x32\xa5\x89\x49\x80\x33\x6a\x3b\xaf\xf2\xbb\x3d\x8c\xfe\xfc\x09\x3c\xc5\xb0\x68\x7f\x73\xf4\x37\x1a\xea\x51\xba\xbf\xaf\x51\xd6\x5a\x42\x2e\x19\x93\xd8\xef\x05\x03\x3e\x5a\xe3\x01\xc5\xba\x32\xae\x37\x79\x89\x1c\x50\x30\xc1\xdb\x9b\x2b\x48\xe5\x56\x30\xb1\x5a\x8a\xba\x8f\xe5\x89\x1c\xf5\x8c\x79\x36\xb7\x69\xed\x9e\xe7\xfd\xe3\xe2\xfa\x87\xbd\x6b\x88\xef\x3e\x2e\xae\x27\xb7\xcd\x1f\x75\x2e\x8f\x9d\x43\x8b\xef\x1f\x3f\xba\x0f\xac\x49\x38\x74\xda\xcf\x3b\x5a\x84\x5a\x8c\xf9\xf8\x30\xb8\xde\x22\x33\x39\x2c\x61\xee\xda\x7a\xd8\xa4\xfd\xff\xfa\x25\x24\xb4\x51\x8f\xf4\x33\x0a\xc3\xff\xe7\xd5\x33\x9b\x5e\xa7\x36\x31\x8e\x37\x8f\x8b\xfd\xcd\x6a\x41\xc3\xa2\x8b\xdd\x45\x68\xfc\xdc\xcf\xd0\x43\x48\x67\xd8\x1e\xeb\x87\xd0\xf8\x3d\x1d\x0d\x23\x38\x1c\x84\x23\xf0\xcd\x29\xf4\xe0\x97\x43\xe8\xb0\x01\x1f\xfe\xa2\xb2\x3f\x40\x3e\x2e\xae\x47\x64\xd0\xc7\x1e\xe8\x13\xad\xaf\x88\xe1\x65\x35\x3c\xa2\x92\x18\xc9\xa5\xee\xc6\x5a\xec\x04\xdd\xfb\xc3\x07\xcb\xc9\xc9\x83\x97\x4a\xf9\xd4\x52\x95\x67\x56\xaa\xe1\xf3\x17\x7d\xb2\x48\x64\x74\xbb\x27\x44\xaa\xe1\xf3\x97\xd9\x3f\x03\x00\x95\x40\x67\x7a\xc9\x0d\x00\x00")
 
 func configCrdsKudoDev_testsuitesYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -176,7 +176,7 @@ func configCrdsKudoDev_testsuitesYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "config/crds/kudo.dev_testsuites.yaml", size: 3529, mode: os.FileMode(420), modTime: time.Unix(1576740834, 0)}
+	info := bindataFileInfo{name: "config/crds/kudo.dev_testsuites.yaml", size: 3529, mode: os.FileMode(436), modTime: time.Unix(1576882156, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
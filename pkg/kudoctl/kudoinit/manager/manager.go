@@ -2,6 +2,7 @@ package manager
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -16,6 +17,7 @@ import (
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"sigs.k8s.io/yaml"
 
+	configv1beta1 "github.com/kudobuilder/kudo/pkg/apis/config/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/kube"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/kudoinit"
@@ -24,28 +26,41 @@ import (
 // Ensure kudoinit.InitStep is implemented
 var _ kudoinit.InitStep = &Initializer{}
 
+const (
+	configMapName = "kudo-manager-config"
+	configFileKey = "config.yaml"
+	configDir     = "/etc/kudo"
+)
+
 //Defines the deployment of the KUDO manager and it's service definition.
 type Initializer struct {
 	options    kudoinit.Options
+	config     *v1.ConfigMap
 	service    *v1.Service
 	deployment *appsv1.StatefulSet
 }
 
 func (m Initializer) AsArray() []runtime.Object {
-	return []runtime.Object{m.service, m.deployment}
+	return []runtime.Object{m.config, m.service, m.deployment}
 }
 
 // NewInitializer returns the setup management object
 func NewInitializer(options kudoinit.Options) Initializer {
+	cfg := GenerateConfig(options)
 	return Initializer{
 		options:    options,
+		config:     generateConfigMap(options, cfg),
 		service:    generateService(options),
-		deployment: generateDeployment(options),
+		deployment: generateDeployment(options, cfg),
 	}
 }
 
 // Install uses Kubernetes client to install KUDO.
 func (m Initializer) Install(client *kube.Client) error {
+	if err := m.installConfigMap(client.KubeClient.CoreV1()); err != nil {
+		return err
+	}
+
 	if err := m.installStatefulSet(client.KubeClient.AppsV1()); err != nil {
 		return err
 	}
@@ -68,6 +83,18 @@ func (m Initializer) installStatefulSet(client appsv1client.StatefulSetsGetter)
 	return err
 }
 
+func (m Initializer) installConfigMap(client corev1.ConfigMapsGetter) error {
+	_, err := client.ConfigMaps(m.options.Namespace).Create(m.config)
+	if kerrors.IsAlreadyExists(err) {
+		clog.V(4).Printf("config map %v already exists", m.config.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("config map: %v", err)
+	}
+	return err
+}
+
 func (m Initializer) installService(client corev1.ServicesGetter) error {
 	_, err := client.Services(m.options.Namespace).Create(m.service)
 	if kerrors.IsAlreadyExists(err) {
@@ -82,10 +109,7 @@ func (m Initializer) installService(client corev1.ServicesGetter) error {
 
 // AsYamlManifests provides a slice of strings for the deployment and service manifest
 func (m Initializer) AsYamlManifests() ([]string, error) {
-	s := m.service
-	d := m.deployment
-
-	objs := []runtime.Object{s, d}
+	objs := m.AsArray()
 
 	manifests := make([]string, len(objs))
 	for i, obj := range objs {
@@ -104,10 +128,55 @@ func GenerateLabels() labels.Set {
 	return kudoinit.GenerateLabels(map[string]string{"control-plane": "controller-manager"})
 }
 
-func generateDeployment(opts kudoinit.Options) *appsv1.StatefulSet {
+// GenerateConfig returns the configuration of the manager. It is based on the manager configuration passed in the
+// options, the settings managed by init (webhooks, watched namespaces and leader election) are set from the options.
+func GenerateConfig(opts kudoinit.Options) *configv1beta1.ManagerConfiguration {
+	cfg := configv1beta1.NewManagerConfiguration()
+	if opts.ManagerConfig != nil {
+		cfg = opts.ManagerConfig.DeepCopy()
+	}
+	cfg.Webhook.Enabled = opts.HasWebhooksEnabled()
+	cfg.WatchNamespaces = opts.WatchNamespaces
+	cfg.LeaderElection.Enabled = true
+	cfg.LeaderElection.Namespace = opts.Namespace
+	return cfg
+}
+
+func generateConfigMap(opts kudoinit.Options, cfg *configv1beta1.ManagerConfiguration) *v1.ConfigMap {
+	// the configuration only consists of plain values, marshaling it can not fail
+	data, _ := yaml.Marshal(cfg)
+	return &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: opts.Namespace,
+			Name:      configMapName,
+			Labels:    GenerateLabels(),
+		},
+		Data: map[string]string{configFileKey: string(data)},
+	}
+}
+
+// bindPort returns the port of a bind address like ":8080", false if the address is disabled or has no valid port
+func bindPort(addr string) (int32, bool) {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return 0, false
+	}
+	p, err := strconv.ParseInt(port, 10, 32)
+	if err != nil || p <= 0 {
+		return 0, false
+	}
+	return int32(p), true
+}
+
+func generateDeployment(opts kudoinit.Options, cfg *configv1beta1.ManagerConfiguration) *appsv1.StatefulSet {
 	managerLabels := GenerateLabels()
 
 	secretDefaultMode := int32(420)
+	configDefaultMode := int32(420)
 	image := opts.Image
 	s := &appsv1.StatefulSet{
 		TypeMeta: metav1.TypeMeta{
@@ -120,6 +189,7 @@ func generateDeployment(opts kudoinit.Options) *appsv1.StatefulSet {
 			Labels:    managerLabels,
 		},
 		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &opts.Replicas,
 			Selector:    &metav1.LabelSelector{MatchLabels: managerLabels},
 			ServiceName: "kudo-controller-manager-service",
 			Template: v1.PodTemplateSpec{
@@ -131,48 +201,91 @@ func generateDeployment(opts kudoinit.Options) *appsv1.StatefulSet {
 					Containers: []v1.Container{
 						{
 							Command: []string{"/root/manager"},
+							Args:    []string{fmt.Sprintf("--config=%s/%s", configDir, configFileKey)},
 							Env: []v1.EnvVar{
 								{Name: "POD_NAMESPACE", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
 								{Name: "SECRET_NAME", Value: "kudo-webhook-server-secret"},
-								{Name: "ENABLE_WEBHOOKS", Value: strconv.FormatBool(opts.HasWebhooksEnabled())},
 							},
 							Image:           image,
 							ImagePullPolicy: "Always",
 							Name:            "manager",
 							Ports: []v1.ContainerPort{
 								// name matters for service
-								{ContainerPort: 443, Name: "webhook-server", Protocol: "TCP"},
+								{ContainerPort: int32(cfg.Webhook.Port), Name: "webhook-server", Protocol: "TCP"},
 							},
 							Resources: v1.ResourceRequirements{
 								Requests: v1.ResourceList{
 									"cpu":    resource.MustParse("100m"),
 									"memory": resource.MustParse("50Mi")},
 							},
+							VolumeMounts: []v1.VolumeMount{
+								{Name: "config", MountPath: configDir, ReadOnly: true},
+							},
 						},
 					},
 					TerminationGracePeriodSeconds: &opts.TerminationGracePeriodSeconds,
+					Volumes: []v1.Volume{
+						{
+							Name: "config",
+							VolumeSource: v1.VolumeSource{
+								ConfigMap: &v1.ConfigMapVolumeSource{
+									LocalObjectReference: v1.LocalObjectReference{Name: configMapName},
+									DefaultMode:          &configDefaultMode,
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 	}
 
-	if opts.HasWebhooksEnabled() {
-		s.Spec.Template.Spec.Containers[0].VolumeMounts = []v1.VolumeMount{
-			{Name: "cert", MountPath: "/tmp/cert", ReadOnly: true},
+	container := &s.Spec.Template.Spec.Containers[0]
+	if port, ok := bindPort(cfg.MetricsBindAddress); ok {
+		container.Ports = append(container.Ports, v1.ContainerPort{ContainerPort: port, Name: "metrics", Protocol: "TCP"})
+	}
+	if port, ok := bindPort(cfg.HealthProbeBindAddress); ok {
+		container.Ports = append(container.Ports, v1.ContainerPort{ContainerPort: port, Name: "health", Protocol: "TCP"})
+		container.LivenessProbe = &v1.Probe{
+			Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("health")}},
+		}
+		container.ReadinessProbe = &v1.Probe{
+			Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{Path: "/readyz", Port: intstr.FromString("health")}},
 		}
-		s.Spec.Template.Spec.Volumes = []v1.Volume{
-			{
-				Name: "cert",
-				VolumeSource: v1.VolumeSource{
-					Secret: &v1.SecretVolumeSource{
-						SecretName:  "kudo-webhook-server-secret",
-						DefaultMode: &secretDefaultMode,
+	}
+
+	// replicas are started in parallel and spread across nodes. only the leader runs the controllers, while the
+	// webhooks are served by all replicas
+	if opts.Replicas > 1 {
+		s.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+		s.Spec.Template.Spec.Affinity = &v1.Affinity{
+			PodAntiAffinity: &v1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: v1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: managerLabels},
+							TopologyKey:   "kubernetes.io/hostname",
+						},
 					},
 				},
 			},
 		}
 	}
 
+	if opts.HasWebhooksEnabled() {
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: "cert", MountPath: cfg.Webhook.CertDir, ReadOnly: true})
+		s.Spec.Template.Spec.Volumes = append(s.Spec.Template.Spec.Volumes, v1.Volume{
+			Name: "cert",
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName:  "kudo-webhook-server-secret",
+					DefaultMode: &secretDefaultMode,
+				},
+			},
+		})
+	}
+
 	return s
 }
 
@@ -3,6 +3,7 @@ package kudoinit
 import (
 	"fmt"
 
+	configv1beta1 "github.com/kudobuilder/kudo/pkg/apis/config/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/version"
 )
 
@@ -19,6 +20,12 @@ type Options struct {
 	// Enable validation
 	Webhooks       []string
 	ServiceAccount string
+	// Replicas is the number of manager replicas, one of them is elected as leader and runs the controllers
+	Replicas int32
+	// WatchNamespaces limits the manager to the given namespaces, it watches all namespaces if empty
+	WatchNamespaces []string
+	// ManagerConfig is the base of the generated manager configuration, the defaults are used if nil
+	ManagerConfig *configv1beta1.ManagerConfiguration
 }
 
 func NewOptions(v string, ns string, sa string, webhooks []string) Options {
@@ -39,6 +46,7 @@ func NewOptions(v string, ns string, sa string, webhooks []string) Options {
 		Image:                         fmt.Sprintf("kudobuilder/controller:v%v", v),
 		Webhooks:                      webhooks,
 		ServiceAccount:                sa,
+		Replicas:                      1,
 	}
 }
 
@@ -46,6 +54,23 @@ func (o Options) HasWebhooksEnabled() bool {
 	return len(o.Webhooks) != 0
 }
 
+// IsNamespaced returns true if the manager is limited to the watched namespaces and only needs namespaced permissions
+func (o Options) IsNamespaced() bool {
+	return len(o.WatchNamespaces) != 0
+}
+
+// RBACNamespaces returns the namespaces the manager needs permissions in: the watched namespaces and the KUDO
+// namespace, where it holds the leader election lock and publishes events
+func (o Options) RBACNamespaces() []string {
+	namespaces := []string{o.Namespace}
+	for _, ns := range o.WatchNamespaces {
+		if ns != o.Namespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 func (o Options) IsDefaultNamespace() bool {
 	return o.Namespace == DefaultNamespace
 }
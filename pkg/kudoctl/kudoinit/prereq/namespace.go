@@ -29,6 +29,13 @@ func newNamespace(options kudoinit.Options) kudoNamespace {
 }
 
 func (o kudoNamespace) Install(client *kube.Client) error {
+	if err := o.installNamespace(client); err != nil {
+		return err
+	}
+	return o.labelWatchedNamespaces(client)
+}
+
+func (o kudoNamespace) installNamespace(client *kube.Client) error {
 	// We only manage kudo-system namespace. For others we expect they exist.
 	if !o.opts.IsDefaultNamespace() {
 		_, err := client.KubeClient.CoreV1().Namespaces().Get(o.opts.Namespace, metav1.GetOptions{})
@@ -49,6 +56,38 @@ func (o kudoNamespace) Install(client *kube.Client) error {
 	return err
 }
 
+// labelWatchedNamespaces sets the kudoinit.WatchedNamespaceLabel on the watched namespaces, which have to exist. A
+// namespace can only be watched by one KUDO installation.
+func (o kudoNamespace) labelWatchedNamespaces(client *kube.Client) error {
+	for _, name := range o.opts.WatchNamespaces {
+		ns, err := client.KubeClient.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("watched namespace %s does not exist - KUDO expects that watched namespaces are created beforehand", name)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch ns.Labels[kudoinit.WatchedNamespaceLabel] {
+		case o.opts.Namespace:
+			clog.V(4).Printf("namespace %v is already labeled", name)
+			continue
+		case "":
+		default:
+			return fmt.Errorf("namespace %s is already watched by the KUDO installation in namespace %s", name, ns.Labels[kudoinit.WatchedNamespaceLabel])
+		}
+
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		ns.Labels[kudoinit.WatchedNamespaceLabel] = o.opts.Namespace
+		if _, err := client.KubeClient.CoreV1().Namespaces().Update(ns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (o kudoNamespace) ValidateInstallation(client *kube.Client) error {
 	return nil
 }
@@ -0,0 +1,44 @@
+package prereq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kudobuilder/kudo/pkg/kudoctl/kube"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/kudoinit"
+)
+
+func TestNamespace_LabelsWatchedNamespaces(t *testing.T) {
+	namespace := func(name string, labels map[string]string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	client := &kube.Client{KubeClient: fake.NewSimpleClientset(
+		namespace("kudo-system", nil),
+		namespace("team-a", map[string]string{"team": "a"}),
+		namespace("team-b", map[string]string{kudoinit.WatchedNamespaceLabel: "kudo-system"}),
+		namespace("team-c", map[string]string{kudoinit.WatchedNamespaceLabel: "kudo-other"}),
+	)}
+
+	opts := kudoinit.NewOptions("", "", "", nil)
+	opts.WatchNamespaces = []string{"team-a", "team-b"}
+	assert.NoError(t, newNamespace(opts).Install(client))
+
+	for _, name := range opts.WatchNamespaces {
+		ns, err := client.KubeClient.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "kudo-system", ns.Labels[kudoinit.WatchedNamespaceLabel], name)
+	}
+	ns, err := client.KubeClient.CoreV1().Namespaces().Get("team-a", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", ns.Labels["team"])
+
+	opts.WatchNamespaces = []string{"team-c"}
+	assert.EqualError(t, newNamespace(opts).Install(client), "namespace team-c is already watched by the KUDO installation in namespace kudo-other")
+
+	opts.WatchNamespaces = []string{"missing"}
+	assert.EqualError(t, newNamespace(opts).Install(client), "watched namespace missing does not exist - KUDO expects that watched namespaces are created beforehand")
+}
@@ -18,18 +18,30 @@ import (
 // Ensure IF is implemented
 var _ k8sResource = &kudoServiceAccount{}
 
+// kudoServiceAccount is the service account of the manager. It is bound to the cluster-admin role, or, when the
+// manager is limited to watched namespaces, to a role with full permissions in each of these namespaces.
 type kudoServiceAccount struct {
 	opts           kudoinit.Options
 	serviceAccount *v1.ServiceAccount
 	roleBinding    *rbacv1.ClusterRoleBinding
+	roles          []*rbacv1.Role
+	roleBindings   []*rbacv1.RoleBinding
 }
 
 func newServiceAccount(options kudoinit.Options) kudoServiceAccount {
-	return kudoServiceAccount{
+	sa := kudoServiceAccount{
 		opts:           options,
 		serviceAccount: generateServiceAccount(options),
-		roleBinding:    generateRoleBinding(options),
 	}
+	if options.IsNamespaced() {
+		for _, ns := range options.RBACNamespaces() {
+			sa.roles = append(sa.roles, generateRole(ns))
+			sa.roleBindings = append(sa.roleBindings, generateNamespacedRoleBinding(options, ns))
+		}
+	} else {
+		sa.roleBinding = generateRoleBinding(options)
+	}
+	return sa
 }
 
 func (o kudoServiceAccount) Install(client *kube.Client) error {
@@ -38,6 +50,10 @@ func (o kudoServiceAccount) Install(client *kube.Client) error {
 		if err := o.validateServiceAccountExists(client); err != nil {
 			return err
 		}
+		if o.opts.IsNamespaced() {
+			// Validate the alternate serviceaccount is bound to a role in all watched namespaces
+			return o.validateRoleBindingsForSA(client)
+		}
 		// Validate the alternate serviceaccount has cluster-admin clusterrolebinding
 		if err := o.validateClusterAdminRoleForSA(client); err != nil {
 			return err
@@ -46,6 +62,9 @@ func (o kudoServiceAccount) Install(client *kube.Client) error {
 		if err := o.installServiceAccount(client); err != nil {
 			return err
 		}
+		if o.opts.IsNamespaced() {
+			return o.installRoles(client)
+		}
 		if err := o.installRoleBinding(client); err != nil {
 			return err
 		}
@@ -89,6 +108,31 @@ func (o kudoServiceAccount) validateClusterAdminRoleForSA(client *kube.Client) e
 	return fmt.Errorf("Service Account %s does not have cluster-admin role - KUDO expects the serviceAccount passed to be in the namespace %s and to have cluster-admin role", o.opts.ServiceAccount, o.opts.Namespace)
 }
 
+// Validate whether the serviceAccount is bound to a role in every namespace the manager needs permissions in
+func (o kudoServiceAccount) validateRoleBindingsForSA(client *kube.Client) error {
+	for _, ns := range o.opts.RBACNamespaces() {
+		rbs, err := client.KubeClient.RbacV1().RoleBindings(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		if !hasSubject(rbs.Items, o.opts.ServiceAccount, o.opts.Namespace) {
+			return fmt.Errorf("Service Account %s does not have a role binding in namespace %s - KUDO expects the serviceAccount passed to be in the namespace %s and to have a role in all watched namespaces", o.opts.ServiceAccount, ns, o.opts.Namespace)
+		}
+	}
+	return nil
+}
+
+func hasSubject(rbs []rbacv1.RoleBinding, name, namespace string) bool {
+	for _, rb := range rbs {
+		for _, subject := range rb.Subjects {
+			if subject.Kind == "ServiceAccount" && subject.Name == name && subject.Namespace == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (o kudoServiceAccount) installServiceAccount(client *kube.Client) error {
 	coreClient := client.KubeClient.CoreV1()
 	_, err := coreClient.ServiceAccounts(o.opts.Namespace).Create(o.serviceAccount)
@@ -108,6 +152,26 @@ func (o kudoServiceAccount) installRoleBinding(client *kube.Client) error {
 	return err
 }
 
+func (o kudoServiceAccount) installRoles(client *kube.Client) error {
+	for i, role := range o.roles {
+		_, err := client.KubeClient.RbacV1().Roles(role.Namespace).Create(role)
+		if kerrors.IsAlreadyExists(err) {
+			clog.V(4).Printf("role %s/%s already exists", role.Namespace, role.Name)
+		} else if err != nil {
+			return err
+		}
+
+		rb := o.roleBindings[i]
+		_, err = client.KubeClient.RbacV1().RoleBindings(rb.Namespace).Create(rb)
+		if kerrors.IsAlreadyExists(err) {
+			clog.V(4).Printf("role binding %s/%s already exists", rb.Namespace, rb.Name)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (o kudoServiceAccount) ValidateInstallation(client *kube.Client) error {
 	coreClient := client.KubeClient.CoreV1()
 
@@ -120,6 +184,19 @@ func (o kudoServiceAccount) ValidateInstallation(client *kube.Client) error {
 		return fmt.Errorf("installed ServiceAccount does not equal expected service account")
 	}
 
+	if o.opts.IsNamespaced() {
+		for _, rb := range o.roleBindings {
+			existingRB, err := client.KubeClient.RbacV1().RoleBindings(rb.Namespace).Get(rb.Name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to retrieve role binding %v", err)
+			}
+			if !reflect.DeepEqual(existingRB, rb) {
+				return fmt.Errorf("installed RoleBinding in namespace %s does not equal expected", rb.Namespace)
+			}
+		}
+		return nil
+	}
+
 	existingRB, err := client.KubeClient.RbacV1().RoleBindings(o.opts.Namespace).Get(o.roleBinding.Name, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to retrieve role binding %v", err)
@@ -133,6 +210,13 @@ func (o kudoServiceAccount) ValidateInstallation(client *kube.Client) error {
 
 func (o kudoServiceAccount) AsRuntimeObjs() []runtime.Object {
 	if o.opts.IsDefaultServiceAccount() {
+		if o.opts.IsNamespaced() {
+			objs := []runtime.Object{o.serviceAccount}
+			for i := range o.roles {
+				objs = append(objs, o.roles[i], o.roleBindings[i])
+			}
+			return objs
+		}
 		return []runtime.Object{o.serviceAccount, o.roleBinding}
 	}
 	return make([]runtime.Object, 0)
@@ -175,3 +259,48 @@ func generateRoleBinding(opts kudoinit.Options) *rbacv1.ClusterRoleBinding {
 		},
 	}
 }
+
+// generateRole builds a role with full permissions in a namespace, used instead of cluster-admin when the manager is
+// limited to watched namespaces
+func generateRole(ns string) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:    kudoinit.GenerateLabels(map[string]string{}),
+			Name:      "kudo-manager-role",
+			Namespace: ns,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"*"},
+			Resources: []string{"*"},
+			Verbs:     []string{"*"},
+		}},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+	}
+}
+
+func generateNamespacedRoleBinding(opts kudoinit.Options, ns string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:    kudoinit.GenerateLabels(map[string]string{}),
+			Name:      "kudo-manager-rolebinding",
+			Namespace: ns,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "kudo-manager-role",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      opts.ServiceAccount,
+			Namespace: opts.Namespace,
+		}},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+	}
+}
@@ -40,7 +40,7 @@ func (k kudoWebHook) Install(client *kube.Client) error {
 	if err := installUnstructured(client.DynamicClient, certificate(k.opts.Namespace)); err != nil {
 		return err
 	}
-	if err := installAdmissionWebhook(client.KubeClient.AdmissionregistrationV1beta1(), instanceUpdateValidatingWebhook(k.opts.Namespace)); err != nil {
+	if err := installAdmissionWebhook(client.KubeClient.AdmissionregistrationV1beta1(), instanceUpdateValidatingWebhook(k.opts)); err != nil {
 		return err
 	}
 	return nil
@@ -60,7 +60,7 @@ func (k kudoWebHook) AsRuntimeObjs() []runtime.Object {
 		return make([]runtime.Object, 0)
 	}
 
-	av := instanceUpdateValidatingWebhook(k.opts.Namespace)
+	av := instanceUpdateValidatingWebhook(k.opts)
 	cert := certificate(k.opts.Namespace)
 	objs := []runtime.Object{&av}
 	for _, c := range cert {
@@ -98,14 +98,25 @@ func installAdmissionWebhook(client clientv1beta1.ValidatingWebhookConfiguration
 	return err
 }
 
-func instanceUpdateValidatingWebhook(ns string) admissionv1beta1.ValidatingWebhookConfiguration {
+// instanceUpdateValidatingWebhook returns the instance validation webhook configuration. The configuration of every
+// namespaced installation is named after its KUDO namespace to allow several installations in a cluster, and only
+// selects the namespaces labeled as watched by the installation (see kudoinit.WatchedNamespaceLabel). Instances of
+// other namespaces are neither validated by nor depend on the availability of the installation.
+func instanceUpdateValidatingWebhook(opts kudoinit.Options) admissionv1beta1.ValidatingWebhookConfiguration {
+	ns := opts.Namespace
+	name := "kudo-manager-instance-validation-webhook-config"
+	var namespaceSelector *metav1.LabelSelector
+	if opts.IsNamespaced() {
+		name = fmt.Sprintf("%s-%s", name, ns)
+		namespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{kudoinit.WatchedNamespaceLabel: ns}}
+	}
 	namespacedScope := admissionv1beta1.NamespacedScope
 	failedType := admissionv1beta1.Fail
 	equivalentType := admissionv1beta1.Equivalent
 	noSideEffects := admissionv1beta1.SideEffectClassNone
 	return admissionv1beta1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "kudo-manager-instance-validation-webhook-config",
+			Name: name,
 			Annotations: map[string]string{
 				"cert-manager.io/inject-ca-from": fmt.Sprintf("%s/kudo-webhook-server-certificate", ns),
 			},
@@ -128,9 +139,10 @@ func instanceUpdateValidatingWebhook(ns string) admissionv1beta1.ValidatingWebho
 						},
 					},
 				},
-				FailurePolicy: &failedType, // this means that the request to update instance would fail, if webhook is not up
-				MatchPolicy:   &equivalentType,
-				SideEffects:   &noSideEffects,
+				NamespaceSelector: namespaceSelector,
+				FailurePolicy:     &failedType, // this means that the request to update instance would fail, if webhook is not up
+				MatchPolicy:       &equivalentType,
+				SideEffects:       &noSideEffects,
 				ClientConfig: admissionv1beta1.WebhookClientConfig{
 					Service: &admissionv1beta1.ServiceReference{
 						Name:      "kudo-controller-manager-service",
@@ -10,6 +10,10 @@ const (
 	DefaultNamespace      = "kudo-system"
 	defaultGracePeriod    = 10
 	defaultServiceAccount = "kudo-manager"
+
+	// WatchedNamespaceLabel is set on the namespaces watched by a namespaced KUDO installation, its value is the KUDO
+	// namespace of the installation. The instance validation webhook of the installation is limited to these namespaces.
+	WatchedNamespaceLabel = "kudo.dev/watched-by"
 )
 
 type InitStep interface {
@@ -0,0 +1,249 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/engine/task"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/render"
+	"github.com/kudobuilder/kudo/pkg/util/kudo"
+)
+
+// Format is an output format of a plan graph
+type Format string
+
+const (
+	// DOT is the Graphviz graph description language
+	DOT Format = "dot"
+	// Mermaid is the graph syntax of the Mermaid diagramming tool, supported e.g. in GitHub markdown
+	Mermaid Format = "mermaid"
+)
+
+type nodeKind int
+
+const (
+	planNode nodeKind = iota
+	phaseNode
+	stepNode
+	taskNode
+	templateNode
+	parameterNode
+)
+
+type node struct {
+	id    string
+	label string
+	kind  nodeKind
+}
+
+type edge struct {
+	from, to string
+	label    string
+	// dashed edges show the execution order of serial phases and steps
+	dashed bool
+}
+
+type graph struct {
+	name  string
+	nodes []node
+	edges []edge
+	ids   map[string]string
+}
+
+func (g *graph) has(kind nodeKind, key string) bool {
+	_, ok := g.ids[fmt.Sprintf("%d/%s", kind, key)]
+	return ok
+}
+
+// node returns the id of a node, adding the node to the graph if it doesn't exist yet
+func (g *graph) node(kind nodeKind, key, label string) string {
+	k := fmt.Sprintf("%d/%s", kind, key)
+	if id, ok := g.ids[k]; ok {
+		return id
+	}
+	id := fmt.Sprintf("n%d", len(g.nodes)+1)
+	g.ids[k] = id
+	g.nodes = append(g.nodes, node{id: id, label: label, kind: kind})
+	return id
+}
+
+func (g *graph) edge(from, to, label string, dashed bool) {
+	g.edges = append(g.edges, edge{from: from, to: to, label: label, dashed: dashed})
+}
+
+// Write writes a graph of the plans of an operator package in the given format. The graph contains the phases, steps
+// and tasks of every plan, the templates used by the tasks and the parameters triggering the plans. Phases and steps
+// are connected to their plan and phase, serial phases and steps are additionally connected in the order they are
+// executed. Parameters without a trigger plan trigger the update plan, falling back to the deploy plan, like they do
+// for an instance. Tasks and templates used by several steps or plans are shown once. All plans are included if no plan
+// names are passed.
+func Write(w io.Writer, pf *packages.Files, plans []string, format Format) error {
+	if len(plans) == 0 {
+		plans = render.PlanNames(pf)
+	}
+
+	g := &graph{name: pf.Operator.Name, ids: map[string]string{}}
+	ov := &v1beta1.OperatorVersion{Spec: v1beta1.OperatorVersionSpec{Plans: pf.Operator.Plans}}
+	for _, name := range plans {
+		plan, ok := pf.Operator.Plans[name]
+		if !ok {
+			return fmt.Errorf("plan %s not found", name)
+		}
+		if err := g.addPlan(name, plan, pf.Operator.Tasks); err != nil {
+			return err
+		}
+		for _, p := range pf.Params.Parameters {
+			if kudo.StringValue(p.TriggeredPlan(ov)) == name {
+				g.edge(g.node(parameterNode, p.Name, p.Name), g.node(planNode, name, ""), "triggers", false)
+			}
+		}
+	}
+
+	switch format {
+	case DOT:
+		writeDOT(w, g)
+	case Mermaid:
+		writeMermaid(w, g)
+	default:
+		return fmt.Errorf("unknown graph format %s, supported are %s and %s", format, DOT, Mermaid)
+	}
+	return nil
+}
+
+func (g *graph) addPlan(name string, plan v1beta1.Plan, tasks []v1beta1.Task) error {
+	planID := g.node(planNode, name, fmt.Sprintf("plan %s\n(%s)", name, strategy(plan.Strategy)))
+
+	prevPhase := ""
+	for _, ph := range plan.Phases {
+		phaseKey := name + "/" + ph.Name
+		phaseID := g.node(phaseNode, phaseKey, fmt.Sprintf("phase %s\n(%s)", ph.Name, strategy(ph.Strategy)))
+		g.edge(planID, phaseID, "", false)
+		if prevPhase != "" && plan.Strategy != v1beta1.Parallel {
+			g.edge(prevPhase, phaseID, "then", true)
+		}
+		prevPhase = phaseID
+
+		prevStep := ""
+		for _, st := range ph.Steps {
+			stepID := g.node(stepNode, phaseKey+"/"+st.Name, fmt.Sprintf("step %s", st.Name))
+			g.edge(phaseID, stepID, "", false)
+			if prevStep != "" && ph.Strategy != v1beta1.Parallel {
+				g.edge(prevStep, stepID, "then", true)
+			}
+			prevStep = stepID
+
+			for _, tn := range st.Tasks {
+				t, ok := render.TaskByName(tasks, tn)
+				if !ok {
+					return fmt.Errorf("task %s of step %s.%s.%s not found", tn, name, ph.Name, st.Name)
+				}
+				g.edge(stepID, g.addTask(t), "", false)
+			}
+		}
+	}
+	return nil
+}
+
+// addTask adds a task and the templates it uses, unless the task was already added for another step
+func (g *graph) addTask(t *v1beta1.Task) string {
+	if g.has(taskNode, t.Name) {
+		return g.node(taskNode, t.Name, "")
+	}
+	taskID := g.node(taskNode, t.Name, fmt.Sprintf("task %s\n%s", t.Name, t.Kind))
+
+	var templates []string
+	switch t.Kind {
+	case task.ApplyTaskKind, task.DeleteTaskKind:
+		templates = t.Spec.ResourceTaskSpec.Resources
+	case task.PipeTaskKind:
+		templates = []string{t.Spec.PipeTaskSpec.Pod}
+	case task.HelmTaskKind:
+		if t.Spec.HelmTaskSpec.Values != "" {
+			templates = append(templates, t.Spec.HelmTaskSpec.Values)
+		}
+		templates = append(templates, fmt.Sprintf("%s/%s", task.ChartsDir, t.Spec.HelmTaskSpec.Chart))
+	}
+	for _, tpl := range templates {
+		g.edge(taskID, g.node(templateNode, tpl, tpl), "", false)
+	}
+	return taskID
+}
+
+func strategy(o v1beta1.Ordering) v1beta1.Ordering {
+	if o == "" {
+		return v1beta1.Serial
+	}
+	return o
+}
+
+func writeDOT(w io.Writer, g *graph) {
+	shapes := map[nodeKind]string{
+		planNode:      `shape=box, style=bold`,
+		phaseNode:     `shape=box, style=rounded`,
+		stepNode:      `shape=box`,
+		taskNode:      `shape=hexagon`,
+		templateNode:  `shape=note`,
+		parameterNode: `shape=ellipse`,
+	}
+	quote := func(s string) string {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+		s = strings.ReplaceAll(s, `"`, `\"`)
+		return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+	}
+
+	fmt.Fprintf(w, "digraph %s {\n", quote(g.name))
+	fmt.Fprintln(w, "  rankdir=LR;")
+	for _, n := range g.nodes {
+		fmt.Fprintf(w, "  %s [label=%s, %s];\n", n.id, quote(n.label), shapes[n.kind])
+	}
+	for _, e := range g.edges {
+		var attrs []string
+		if e.label != "" {
+			attrs = append(attrs, "label="+quote(e.label))
+		}
+		if e.dashed {
+			attrs = append(attrs, "style=dashed")
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(w, "  %s -> %s [%s];\n", e.from, e.to, strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(w, "  %s -> %s;\n", e.from, e.to)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func writeMermaid(w io.Writer, g *graph) {
+	shapes := map[nodeKind][2]string{
+		planNode:      {"[[", "]]"},
+		phaseNode:     {"(", ")"},
+		stepNode:      {"[", "]"},
+		taskNode:      {"{{", "}}"},
+		templateNode:  {"[/", "/]"},
+		parameterNode: {"([", "])"},
+	}
+	quote := func(s string) string {
+		s = strings.ReplaceAll(s, `"`, "#quot;")
+		return `"` + strings.ReplaceAll(s, "\n", "<br/>") + `"`
+	}
+
+	fmt.Fprintln(w, "graph LR")
+	for _, n := range g.nodes {
+		shape := shapes[n.kind]
+		fmt.Fprintf(w, "  %s%s%s%s\n", n.id, shape[0], quote(n.label), shape[1])
+	}
+	for _, e := range g.edges {
+		arrow := "-->"
+		if e.dashed {
+			arrow = "-.->"
+		}
+		if e.label != "" {
+			fmt.Fprintf(w, "  %s %s|%s| %s\n", e.from, arrow, e.label, e.to)
+		} else {
+			fmt.Fprintf(w, "  %s %s %s\n", e.from, arrow, e.to)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+)
+
+func testPackage() *packages.Files {
+	return &packages.Files{
+		Operator: &packages.OperatorFile{
+			Name: "op",
+			Tasks: []v1beta1.Task{
+				{Name: "app", Kind: "Apply", Spec: v1beta1.TaskSpec{ResourceTaskSpec: v1beta1.ResourceTaskSpec{Resources: []string{"cm.yaml"}}}},
+				{Name: "noop", Kind: "Dummy"},
+			},
+			Plans: map[string]v1beta1.Plan{
+				"deploy": {Phases: []v1beta1.Phase{{Name: "main", Steps: []v1beta1.Step{
+					{Name: "first", Tasks: []string{"app"}},
+					{Name: "second", Tasks: []string{"noop"}},
+				}}}},
+				"restart": {Phases: []v1beta1.Phase{{Name: "main", Strategy: v1beta1.Parallel, Steps: []v1beta1.Step{
+					{Name: "one", Tasks: []string{"app"}},
+					{Name: "two", Tasks: []string{"noop"}},
+				}}}},
+			},
+		},
+		Params: &packages.ParamsFile{Parameters: []v1beta1.Parameter{{Name: "RESTART", Trigger: "restart"}, {Name: "VALUE"}}},
+	}
+}
+
+func TestWrite_DOT(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, Write(&out, testPackage(), nil, DOT))
+
+	expected := `digraph "op" {
+  rankdir=LR;
+  n1 [label="plan deploy\n(serial)", shape=box, style=bold];
+  n2 [label="phase main\n(serial)", shape=box, style=rounded];
+  n3 [label="step first", shape=box];
+  n4 [label="task app\nApply", shape=hexagon];
+  n5 [label="cm.yaml", shape=note];
+  n6 [label="step second", shape=box];
+  n7 [label="task noop\nDummy", shape=hexagon];
+  n8 [label="VALUE", shape=ellipse];
+  n9 [label="plan restart\n(serial)", shape=box, style=bold];
+  n10 [label="phase main\n(parallel)", shape=box, style=rounded];
+  n11 [label="step one", shape=box];
+  n12 [label="step two", shape=box];
+  n13 [label="RESTART", shape=ellipse];
+  n1 -> n2;
+  n2 -> n3;
+  n4 -> n5;
+  n3 -> n4;
+  n2 -> n6;
+  n3 -> n6 [label="then", style=dashed];
+  n6 -> n7;
+  n8 -> n1 [label="triggers"];
+  n9 -> n10;
+  n10 -> n11;
+  n11 -> n4;
+  n10 -> n12;
+  n12 -> n7;
+  n13 -> n9 [label="triggers"];
+}
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestWrite_Mermaid(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, Write(&out, testPackage(), []string{"restart"}, Mermaid))
+
+	expected := `graph LR
+  n1[["plan restart<br/>(serial)"]]
+  n2("phase main<br/>(parallel)")
+  n3["step one"]
+  n4{{"task app<br/>Apply"}}
+  n5[/"cm.yaml"/]
+  n6["step two"]
+  n7{{"task noop<br/>Dummy"}}
+  n8(["RESTART"])
+  n1 --> n2
+  n2 --> n3
+  n4 --> n5
+  n3 --> n4
+  n2 --> n6
+  n6 --> n7
+  n8 -->|triggers| n1
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestWrite_Errors(t *testing.T) {
+	var out bytes.Buffer
+	assert.Error(t, Write(&out, testPackage(), []string{"missing"}, DOT))
+	assert.Error(t, Write(&out, testPackage(), nil, "svg"))
+}
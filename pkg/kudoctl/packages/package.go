@@ -72,18 +72,22 @@ func (p *Files) Resources() (*Resources, error) {
 		Status: v1beta1.OperatorVersionStatus{},
 	}
 
+	operatorVersionName := fmt.Sprintf("%s-%s", p.Operator.Name, p.Operator.Version)
 	instance := &v1beta1.Instance{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Instance",
 			APIVersion: APIVersion,
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   fmt.Sprintf("%s-instance", p.Operator.Name),
-			Labels: map[string]string{kudo.OperatorLabel: p.Operator.Name},
+			Name: fmt.Sprintf("%s-instance", p.Operator.Name),
+			Labels: map[string]string{
+				kudo.OperatorLabel:        p.Operator.Name,
+				kudo.OperatorVersionLabel: operatorVersionName,
+			},
 		},
 		Spec: v1beta1.InstanceSpec{
 			OperatorVersion: v1.ObjectReference{
-				Name: fmt.Sprintf("%s-%s", p.Operator.Name, p.Operator.Version),
+				Name: operatorVersionName,
 			},
 		},
 		Status: v1beta1.InstanceStatus{},
@@ -110,6 +114,13 @@ func validateTask(t v1beta1.Task, templates map[string]string) []string {
 		if len(t.Spec.PipeTaskSpec.Pipe) == 0 {
 			errs = append(errs, fmt.Sprintf("task %s does not have pipe files specified", t.Name))
 		}
+	case task.HelmTaskKind:
+		if t.Spec.HelmTaskSpec.Values != "" {
+			resources = append(resources, t.Spec.HelmTaskSpec.Values)
+		}
+		if _, ok := task.ChartFiles(templates, t.Spec.HelmTaskSpec.Chart)["Chart.yaml"]; !ok {
+			errs = append(errs, fmt.Sprintf("task %s missing chart: %s", t.Name, t.Spec.HelmTaskSpec.Chart))
+		}
 	case task.DummyTaskKind:
 	default:
 		log.Printf("no validation for task kind %s implemented", t.Kind)
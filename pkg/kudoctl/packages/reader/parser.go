@@ -3,12 +3,15 @@ package reader
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"sigs.k8s.io/yaml"
 
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/engine/task"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
 )
@@ -17,6 +20,7 @@ const (
 	OperatorFileName = "operator.yaml"
 	ParamsFileName   = "params.yaml"
 	templateBase     = "templates"
+	chartsBase       = templateBase + "/" + task.ChartsDir + "/"
 	templateFileName = ".*\\.yaml"
 	APIVersion       = "kudo.dev/v1beta1"
 )
@@ -41,8 +45,12 @@ func parsePackageFile(filePath string, fileBytes []byte, currentPackage *package
 			clog.Printf("Failed to parse template file %s, err: %v", name, err)
 			os.Exit(1)
 		}
+		return base == templateBase && (match || renderer.IsPartial(file))
+	}
 
-		return base == templateBase && match
+	// all files of charts embedded in templates/charts are kept, see task.ChartsDir
+	isChartFile := func(name string) bool {
+		return strings.Contains(name, chartsBase)
 	}
 
 	isParametersFile := func(name string) bool {
@@ -50,6 +58,10 @@ func parsePackageFile(filePath string, fileBytes []byte, currentPackage *package
 	}
 
 	switch {
+	case isChartFile(filePath):
+		pathParts := strings.SplitN(filePath, chartsBase, 2)
+		name := path.Join(task.ChartsDir, pathParts[1])
+		currentPackage.Templates[name] = string(fileBytes)
 	case isOperatorFile(filePath):
 		if err := yaml.Unmarshal(fileBytes, &currentPackage.Operator); err != nil {
 			return fmt.Errorf("failed to unmarshal operator file: %w", err)
@@ -150,3 +150,31 @@ parameters:
 		})
 	}
 }
+
+func Test_parsePackageFile_Templates(t *testing.T) {
+	tests := []struct {
+		path    string
+		name    string
+		wantErr bool
+	}{
+		{path: "operator/templates/pod.yaml", name: "pod.yaml"},
+		{path: "operator/templates/_helpers.tpl", name: "_helpers.tpl"},
+		{path: "operator/templates/_service.yaml", name: "_service.yaml"},
+		{path: "operator/templates/helpers.tpl", wantErr: true},
+		{path: "operator/templates/charts/redis/Chart.yaml", name: "charts/redis/Chart.yaml"},
+		{path: "operator/templates/charts/redis/templates/NOTES.txt", name: "charts/redis/templates/NOTES.txt"},
+		{path: "operator/templates/README.md", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			files := newPackageFiles()
+			err := parsePackageFile(tt.path, []byte("content"), &files)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, "content", files.Templates[tt.name])
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package render
+
+import (
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/engine"
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/engine/task"
+	"github.com/kudobuilder/kudo/pkg/engine/workflow"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+)
+
+// Options define the instance a package is rendered for
+type Options struct {
+	// InstanceName defaults to the operator name appended with -instance
+	InstanceName string
+	// Namespace defaults to "default"
+	Namespace string
+	// Parameters override the parameter defaults of the package
+	Parameters map[string]string
+	// Phase and Step limit rendering to the steps of a phase or to a single step, all steps are rendered if unset
+	Phase string
+	Step  string
+}
+
+// Task is a rendered task of a plan step
+type Task struct {
+	Name    string
+	Kind    string
+	Objects []runtime.Object
+}
+
+// Step contains the rendered tasks of a plan step
+type Step struct {
+	Phase string
+	Name  string
+	Tasks []Task
+}
+
+// Plan renders all tasks of a package plan offline, the same way the KUDO manager renders them for an instance,
+// but without a cluster: the lookup template function is not available and pipe artifacts are not generated.
+// See task.Render for what is rendered for each task kind.
+func Plan(pf *packages.Files, planName string, opts Options) ([]Step, error) {
+	plan, ok := pf.Operator.Plans[planName]
+	if !ok {
+		return nil, fmt.Errorf("plan %s not found", planName)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	emeta := Metadata(pf, opts)
+
+	params := workflow.Params(opts.Parameters, pf.Params.Parameters)
+	pipes, err := workflow.Pipes(planName, &plan, pf.Operator.Tasks, &emeta)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []Step{}
+	for _, ph := range plan.Phases {
+		if opts.Phase != "" && opts.Phase != ph.Name {
+			continue
+		}
+		for _, st := range ph.Steps {
+			if opts.Step != "" && opts.Step != st.Name {
+				continue
+			}
+			step := Step{Phase: ph.Name, Name: st.Name}
+			for _, tn := range st.Tasks {
+				t, ok := TaskByName(pf.Operator.Tasks, tn)
+				if !ok {
+					return nil, fmt.Errorf("task %s of step %s not found", tn, st.Name)
+				}
+				tasker, err := task.Build(t)
+				if err != nil {
+					return nil, fmt.Errorf("failed to build task %s: %w", tn, err)
+				}
+
+				ctx := task.Context{
+					Enhancer: &renderer.KustomizeEnhancer{Scheme: scheme},
+					Meta: renderer.Metadata{
+						Metadata:  emeta,
+						PlanName:  planName,
+						PlanUID:   types.UID(planName),
+						PhaseName: ph.Name,
+						StepName:  st.Name,
+						TaskName:  tn,
+					},
+					Templates:  pf.Templates,
+					Parameters: params,
+					Pipes:      pipes,
+				}
+				objs, err := task.Render(tasker, ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to render task %s: %w", tn, err)
+				}
+				step.Tasks = append(step.Tasks, Task{Name: tn, Kind: t.Kind, Objects: objs})
+			}
+			steps = append(steps, step)
+		}
+	}
+	if len(steps) == 0 && (opts.Phase != "" || opts.Step != "") {
+		return nil, fmt.Errorf("no step of plan %s matches phase %q and step %q", planName, opts.Phase, opts.Step)
+	}
+	return steps, nil
+}
+
+// Metadata returns the engine metadata of the instance a package is rendered for. The instance itself only exists as
+// the owner of the rendered resources.
+func Metadata(pf *packages.Files, opts Options) engine.Metadata {
+	if opts.InstanceName == "" {
+		opts.InstanceName = fmt.Sprintf("%s-instance", pf.Operator.Name)
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+	}
+
+	owner := &v1beta1.Instance{
+		TypeMeta:   metav1.TypeMeta{APIVersion: packages.APIVersion, Kind: "Instance"},
+		ObjectMeta: metav1.ObjectMeta{Name: opts.InstanceName, Namespace: opts.Namespace, UID: types.UID(opts.InstanceName)},
+	}
+	return engine.Metadata{
+		InstanceName:        opts.InstanceName,
+		InstanceNamespace:   opts.Namespace,
+		OperatorName:        pf.Operator.Name,
+		OperatorVersionName: fmt.Sprintf("%s-%s", pf.Operator.Name, pf.Operator.Version),
+		OperatorVersion:     pf.Operator.Version,
+		AppVersion:          pf.Operator.AppVersion,
+		ResourcesOwner:      owner,
+	}
+}
+
+// PlanNames returns the sorted names of all plans of a package
+func PlanNames(pf *packages.Files) []string {
+	names := make([]string, 0, len(pf.Operator.Plans))
+	for name := range pf.Operator.Plans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TaskByName returns the task with the given name
+func TaskByName(tasks []v1beta1.Task, name string) (*v1beta1.Task, bool) {
+	for i := range tasks {
+		if tasks[i].Name == name {
+			return &tasks[i], true
+		}
+	}
+	return nil, false
+}
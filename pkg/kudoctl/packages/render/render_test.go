@@ -0,0 +1,78 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+)
+
+func testPackage() *packages.Files {
+	def := "default"
+	return &packages.Files{
+		Templates: map[string]string{
+			"cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Name }}\ndata:\n  value: {{ .Params.VALUE }}\n",
+		},
+		Operator: &packages.OperatorFile{
+			Name:    "op",
+			Version: "1.0.0",
+			Tasks: []v1beta1.Task{
+				{Name: "app", Kind: "Apply", Spec: v1beta1.TaskSpec{ResourceTaskSpec: v1beta1.ResourceTaskSpec{Resources: []string{"cm.yaml"}}}},
+				{Name: "noop", Kind: "Dummy"},
+			},
+			Plans: map[string]v1beta1.Plan{
+				"deploy": {Phases: []v1beta1.Phase{{Name: "main", Steps: []v1beta1.Step{
+					{Name: "first", Tasks: []string{"app"}},
+					{Name: "second", Tasks: []string{"noop"}},
+				}}}},
+			},
+		},
+		Params: &packages.ParamsFile{Parameters: []v1beta1.Parameter{{Name: "VALUE", Default: &def}}},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	steps, err := Plan(testPackage(), "deploy", Options{Parameters: map[string]string{"VALUE": "custom"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(steps))
+	assert.Equal(t, "first", steps[0].Name)
+	assert.Equal(t, 1, len(steps[0].Tasks[0].Objects))
+
+	cm := steps[0].Tasks[0].Objects[0].(*corev1.ConfigMap)
+	assert.Equal(t, "op-instance", cm.Name)
+	assert.Equal(t, "default", cm.Namespace)
+	assert.Equal(t, "custom", cm.Data["value"])
+	assert.Equal(t, "first", cm.Annotations["kudo.dev/step"])
+
+	assert.Equal(t, "Dummy", steps[1].Tasks[0].Kind)
+	assert.Empty(t, steps[1].Tasks[0].Objects)
+}
+
+func TestPlan_Filter(t *testing.T) {
+	steps, err := Plan(testPackage(), "deploy", Options{Step: "second"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(steps))
+	assert.Equal(t, "second", steps[0].Name)
+
+	_, err = Plan(testPackage(), "deploy", Options{Phase: "missing"})
+	assert.Error(t, err)
+
+	_, err = Plan(testPackage(), "missing", Options{})
+	assert.Error(t, err)
+}
+
+func TestPlan_DuplicatedPipeKey(t *testing.T) {
+	pf := testPackage()
+	pipe := v1beta1.TaskSpec{PipeTaskSpec: v1beta1.PipeTaskSpec{Pod: "pod.yaml", Pipe: []v1beta1.PipeSpec{{File: "foo.txt", Kind: "Secret", Key: "Foo"}}}}
+	pf.Operator.Tasks = append(pf.Operator.Tasks,
+		v1beta1.Task{Name: "pipe-one", Kind: "Pipe", Spec: pipe},
+		v1beta1.Task{Name: "pipe-two", Kind: "Pipe", Spec: pipe},
+	)
+	pf.Operator.Plans["deploy"].Phases[0].Steps[1].Tasks = []string{"pipe-one", "pipe-two"}
+
+	_, err := Plan(pf, "deploy", Options{})
+	assert.EqualError(t, err, "duplicated pipe key Foo")
+}
@@ -0,0 +1,388 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/engine"
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	"github.com/kudobuilder/kudo/pkg/engine/task"
+	"github.com/kudobuilder/kudo/pkg/engine/workflow"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/render"
+)
+
+// DefaultMaxIterations is the default number of plan executions after which a simulation gives up
+const DefaultMaxIterations = 100
+
+// Never can be used in a health timeline for objects which never become healthy
+const Never = -1
+
+// Options of a plan simulation
+type Options struct {
+	render.Options
+
+	// HealthyAfter is the health timeline of the created workloads: it maps workloads, identified by "Kind/name", to
+	// the number of plan executions after their creation before they become healthy. Workloads not in the timeline
+	// are healthy right after the plan execution creating them, those mapped to Never never become healthy.
+	HealthyAfter map[string]int
+	// MaxIterations defaults to DefaultMaxIterations
+	MaxIterations int
+}
+
+// EventType is the type of a simulation event
+type EventType string
+
+const (
+	// TaskExecuted events are recorded for every task that is run during a plan execution
+	TaskExecuted EventType = "TaskExecuted"
+	// StatusChanged events are recorded for every status change of the plan, its phases and steps
+	StatusChanged EventType = "StatusChanged"
+	// ObjectHealthy events are recorded when a created workload is marked healthy
+	ObjectHealthy EventType = "ObjectHealthy"
+)
+
+// Event is something that happened during a plan execution of the simulation
+type Event struct {
+	Iteration int
+	Type      EventType
+	// Subject is the task, plan, phase, step or object the event is about
+	Subject string
+	// From and To are only set for StatusChanged events
+	From    v1beta1.ExecutionStatus
+	To      v1beta1.ExecutionStatus
+	Message string
+}
+
+func (e Event) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%3d %-13s %s", e.Iteration, e.Type, e.Subject)
+	if e.Type == StatusChanged {
+		fmt.Fprintf(&b, ": %s -> %s", e.From, e.To)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, " (%s)", e.Message)
+	}
+	return b.String()
+}
+
+// Result of a plan simulation
+type Result struct {
+	Events     []Event
+	Status     *v1beta1.PlanStatus
+	Iterations int
+}
+
+// createdObject is an object created by the simulated plan
+type createdObject struct {
+	gvk       schema.GroupVersionKind
+	key       client.ObjectKey
+	iteration int
+	healthy   bool
+}
+
+// recordingClient records all objects created through it
+type recordingClient struct {
+	client.Client
+	scheme    *runtime.Scheme
+	iteration int
+	created   []*createdObject
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	key, _ := client.ObjectKeyFromObject(obj)
+	c.created = append(c.created, &createdObject{gvk: gvk, key: key, iteration: c.iteration})
+	return nil
+}
+
+// Plan simulates the execution of a package plan against a fake cluster. The plan is executed with the same
+// workflow engine the KUDO manager uses until it is complete. Between executions, created workloads are marked
+// healthy following the health timeline of the options. Since pipe tasks need a real pod to run, they are
+// simulated as successful tasks without running them.
+//
+// An error is returned if the plan fails with a fatal error or can never complete, the result contains the events
+// up to that point.
+func Plan(pf *packages.Files, planName string, opts Options) (*Result, error) {
+	plan, ok := pf.Operator.Plans[planName]
+	if !ok {
+		return nil, fmt.Errorf("plan %s not found", planName)
+	}
+	if opts.MaxIterations == 0 {
+		opts.MaxIterations = DefaultMaxIterations
+	}
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, apiextv1beta1.AddToScheme, v1beta1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			return nil, err
+		}
+	}
+	emeta := render.Metadata(pf, opts.Options)
+	// the owner instance exists so that unowned resources can be recorded on it
+	c := &recordingClient{Client: fake.NewFakeClientWithScheme(scheme, emeta.ResourcesOwner.(runtime.Object)), scheme: scheme}
+	ov := &v1beta1.OperatorVersion{Spec: v1beta1.OperatorVersionSpec{Plans: pf.Operator.Plans}}
+	instance := &v1beta1.Instance{}
+	if err := instance.StartPlanExecution(planName, ov); err != nil {
+		return nil, err
+	}
+	status := instance.PlanStatus(planName)
+
+	pipes, err := workflow.Pipes(planName, &plan, pf.Operator.Tasks, &emeta)
+	if err != nil {
+		return nil, err
+	}
+
+	ap := &workflow.ActivePlan{
+		Name:       planName,
+		PlanStatus: status,
+		Spec:       &plan,
+		Tasks:      simulatedTasks(pf.Operator.Tasks),
+		Templates:  pf.Templates,
+		Params:     workflow.Params(opts.Parameters, pf.Params.Parameters),
+		Pipes:      pipes,
+	}
+	enhancer := &renderer.KustomizeEnhancer{Scheme: scheme}
+
+	result := &Result{}
+	now := time.Now()
+	for i := 1; i <= opts.MaxIterations; i++ {
+		result.Iterations = i
+		c.iteration = i
+		now = now.Add(time.Second)
+
+		newStatus, err := workflow.Execute(ap, &emeta, c, nil, enhancer, now, log.NullLogger{})
+		result.Events = append(result.Events, executions(i, &plan, ap.PlanStatus, newStatus)...)
+		result.Events = append(result.Events, transitions(i, ap.PlanStatus, newStatus)...)
+		result.Status = newStatus
+
+		if errors.Is(err, engine.ErrFatalExecution) || newStatus.Status == v1beta1.ExecutionFatalError {
+			return result, fmt.Errorf("plan %s failed: %v", planName, err)
+		}
+		if newStatus.Status == v1beta1.ExecutionComplete {
+			return result, nil
+		}
+
+		healthy, pending, err := markHealthy(c, i, opts.HealthyAfter)
+		if err != nil {
+			return result, err
+		}
+		result.Events = append(result.Events, healthy...)
+
+		if len(healthy) == 0 && !pending && reflect.DeepEqual(ap.PlanStatus, newStatus) {
+			return result, fmt.Errorf("plan %s can never complete: %s", planName, stuckReason(newStatus))
+		}
+		ap.PlanStatus = newStatus
+	}
+	return result, fmt.Errorf("plan %s did not complete after %d executions", planName, opts.MaxIterations)
+}
+
+// simulatedTasks replaces pipe tasks with dummy tasks that are done immediately
+func simulatedTasks(tasks []v1beta1.Task) []v1beta1.Task {
+	result := make([]v1beta1.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if t.Kind == task.PipeTaskKind {
+			t = v1beta1.Task{Name: t.Name, Kind: task.DummyTaskKind, Spec: v1beta1.TaskSpec{DummyTaskSpec: v1beta1.DummyTaskSpec{Done: true}}}
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// executions returns the tasks run by a plan execution. The workflow engine runs all tasks of every step that it
+// reaches, which are the unfinished steps it moved out of the pending state.
+func executions(iteration int, plan *v1beta1.Plan, old, new *v1beta1.PlanStatus) []Event {
+	var events []Event
+	for _, ph := range plan.Phases {
+		for _, st := range ph.Steps {
+			before, after := stepStatus(old, ph.Name, st.Name), stepStatus(new, ph.Name, st.Name)
+			if before == v1beta1.ExecutionComplete || after == v1beta1.ExecutionPending {
+				continue
+			}
+			for _, tn := range st.Tasks {
+				events = append(events, Event{Iteration: iteration, Type: TaskExecuted, Subject: fmt.Sprintf("%s.%s.%s", ph.Name, st.Name, tn)})
+			}
+		}
+	}
+	return events
+}
+
+// transitions returns the status changes of the plan, its phases and steps
+func transitions(iteration int, old, new *v1beta1.PlanStatus) []Event {
+	var events []Event
+	add := func(subject string, from, to v1beta1.ExecutionStatus, message string) {
+		if from != to {
+			events = append(events, Event{Iteration: iteration, Type: StatusChanged, Subject: subject, From: from, To: to, Message: message})
+		}
+	}
+
+	add("plan "+new.Name, old.Status, new.Status, "")
+	for i, ph := range new.Phases {
+		add("phase "+ph.Name, old.Phases[i].Status, ph.Status, "")
+		for j, st := range ph.Steps {
+			add(fmt.Sprintf("step %s.%s", ph.Name, st.Name), old.Phases[i].Steps[j].Status, st.Status, st.Message)
+		}
+	}
+	return events
+}
+
+func stepStatus(status *v1beta1.PlanStatus, phase, step string) v1beta1.ExecutionStatus {
+	for _, ph := range status.Phases {
+		if ph.Name != phase {
+			continue
+		}
+		for _, st := range ph.Steps {
+			if st.Name == step {
+				return st.Status
+			}
+		}
+	}
+	return ""
+}
+
+// stuckReason returns the messages of all unfinished steps
+func stuckReason(status *v1beta1.PlanStatus) string {
+	var reasons []string
+	for _, ph := range status.Phases {
+		for _, st := range ph.Steps {
+			if st.Status == v1beta1.ExecutionComplete || st.Status == v1beta1.ExecutionPending {
+				continue
+			}
+			reason := fmt.Sprintf("step %s.%s is %s", ph.Name, st.Name, st.Status)
+			if st.Message != "" {
+				reason = fmt.Sprintf("%s: %s", reason, st.Message)
+			}
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) == 0 {
+		return "no progress"
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// markHealthy marks the created objects healthy which are due according to the health timeline. It returns the
+// resulting events and whether some objects will become healthy during later iterations.
+func markHealthy(c *recordingClient, iteration int, healthyAfter map[string]int) ([]Event, bool, error) {
+	var events []Event
+	pending := false
+
+	for _, o := range c.created {
+		if o.healthy {
+			continue
+		}
+		subject := fmt.Sprintf("%s/%s", o.gvk.Kind, o.key.Name)
+		after := healthyAfter[subject]
+		switch {
+		case after == Never:
+			continue
+		case o.iteration+after > iteration:
+			pending = true
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(o.gvk)
+		err := c.Get(context.TODO(), o.key, u)
+		if err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				o.healthy = true // deleted by a later task
+				continue
+			}
+			return nil, false, err
+		}
+		o.healthy = true
+		workload, err := setHealthyStatus(u)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to mark %s healthy: %w", subject, err)
+		}
+		if !workload {
+			continue
+		}
+		if err := c.Update(context.TODO(), u); err != nil {
+			return nil, false, fmt.Errorf("failed to mark %s healthy: %w", subject, err)
+		}
+		events = append(events, Event{Iteration: iteration, Type: ObjectHealthy, Subject: subject})
+	}
+	return events, pending, nil
+}
+
+// setHealthyStatus sets the status of a workload like the responsible Kubernetes controller would, once the workload
+// is healthy. It returns false for objects which are healthy as soon as they exist, see health.IsHealthy for how the
+// health of each kind is checked.
+func setHealthyStatus(u *unstructured.Unstructured) (bool, error) {
+	replicas, found, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		replicas = 1
+	}
+	generation := u.GetGeneration()
+	if generation == 0 {
+		generation = 1
+		u.SetGeneration(generation)
+	}
+
+	var status map[string]interface{}
+	switch u.GroupVersionKind().GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		status = map[string]interface{}{
+			"observedGeneration": generation,
+			"replicas":           replicas,
+			"updatedReplicas":    replicas,
+			"readyReplicas":      replicas,
+			"availableReplicas":  replicas,
+		}
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		// the API server defaults the update strategy, which is required to check the rollout status
+		if err := unstructured.SetNestedField(u.Object, "RollingUpdate", "spec", "updateStrategy", "type"); err != nil {
+			return false, err
+		}
+		status = map[string]interface{}{
+			"observedGeneration": generation,
+			"replicas":           replicas,
+			"readyReplicas":      replicas,
+			"currentReplicas":    replicas,
+			"updatedReplicas":    replicas,
+			"currentRevision":    "simulated",
+			"updateRevision":     "simulated",
+		}
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		status = map[string]interface{}{"succeeded": int64(1)}
+	case schema.GroupKind{Kind: "Pod"}:
+		status = map[string]interface{}{"phase": "Running"}
+	case schema.GroupKind{Group: apiextv1beta1.GroupName, Kind: "CustomResourceDefinition"}:
+		status = map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{"type": string(apiextv1beta1.Established), "status": string(apiextv1beta1.ConditionTrue)}},
+		}
+	case schema.GroupKind{Group: v1beta1.SchemeGroupVersion.Group, Kind: "Instance"}:
+		status = map[string]interface{}{
+			"aggregatedStatus": map[string]interface{}{"status": string(v1beta1.ExecutionComplete)},
+		}
+	default:
+		return false, nil
+	}
+	return true, unstructured.SetNestedMap(u.Object, status, "status")
+}
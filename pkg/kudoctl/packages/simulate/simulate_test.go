@@ -0,0 +1,143 @@
+package simulate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: app
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      containers:
+      - name: app
+        image: app
+`
+
+const statefulSet = `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  serviceName: db
+  selector:
+    matchLabels:
+      app: db
+  template:
+    metadata:
+      labels:
+        app: db
+    spec:
+      containers:
+      - name: db
+        image: db
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      accessModes: ["ReadWriteOnce"]
+`
+
+func testPackage(strategy v1beta1.Ordering, tasks ...v1beta1.Task) *packages.Files {
+	steps := make([]v1beta1.Step, 0, len(tasks))
+	for _, t := range tasks {
+		steps = append(steps, v1beta1.Step{Name: t.Name, Tasks: []string{t.Name}})
+	}
+	return &packages.Files{
+		Templates: map[string]string{"deployment.yaml": deployment, "statefulset.yaml": statefulSet},
+		Operator: &packages.OperatorFile{
+			Name:    "op",
+			Version: "1.0.0",
+			Tasks:   tasks,
+			Plans: map[string]v1beta1.Plan{
+				"deploy": {Strategy: v1beta1.Serial, Phases: []v1beta1.Phase{{Name: "main", Strategy: strategy, Steps: steps}}},
+			},
+		},
+		Params: &packages.ParamsFile{},
+	}
+}
+
+func apply(name, resource string) v1beta1.Task {
+	return v1beta1.Task{Name: name, Kind: "Apply", Spec: v1beta1.TaskSpec{ResourceTaskSpec: v1beta1.ResourceTaskSpec{Resources: []string{resource}}}}
+}
+
+func dummy(name string, spec v1beta1.DummyTaskSpec) v1beta1.Task {
+	return v1beta1.Task{Name: name, Kind: "Dummy", Spec: v1beta1.TaskSpec{DummyTaskSpec: spec}}
+}
+
+func events(r *Result, t EventType) []string {
+	var subjects []string
+	for _, e := range r.Events {
+		if e.Type == t {
+			subjects = append(subjects, e.Subject)
+		}
+	}
+	return subjects
+}
+
+func TestPlan_Serial(t *testing.T) {
+	pf := testPackage(v1beta1.Serial, apply("app", "deployment.yaml"), apply("db", "statefulset.yaml"))
+
+	result, err := Plan(pf, "deploy", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, v1beta1.ExecutionComplete, result.Status.Status)
+	assert.Equal(t, []string{"main.app.app", "main.app.app", "main.db.db", "main.db.db"}, events(result, TaskExecuted))
+	assert.Equal(t, []string{"Deployment/app", "StatefulSet/db"}, events(result, ObjectHealthy))
+	assert.Equal(t, 3, result.Iterations)
+}
+
+func TestPlan_ClusterScoped(t *testing.T) {
+	pf := testPackage(v1beta1.Serial, apply("role", "role.yaml"))
+	pf.Templates["role.yaml"] = "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: reader\n"
+
+	result, err := Plan(pf, "deploy", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, v1beta1.ExecutionComplete, result.Status.Status)
+}
+
+func TestPlan_HealthTimeline(t *testing.T) {
+	pf := testPackage(v1beta1.Parallel, apply("app", "deployment.yaml"), apply("db", "statefulset.yaml"))
+
+	result, err := Plan(pf, "deploy", Options{HealthyAfter: map[string]int{"StatefulSet/db": 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Iterations)
+	assert.Equal(t, []string{"Deployment/app", "StatefulSet/db"}, events(result, ObjectHealthy))
+	for _, e := range result.Events {
+		if e.Subject == "StatefulSet/db" {
+			assert.Equal(t, 3, e.Iteration, "the statefulset is healthy two executions after its creation")
+		}
+	}
+
+	_, err = Plan(pf, "deploy", Options{HealthyAfter: map[string]int{"StatefulSet/db": Never}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "can never complete")
+}
+
+func TestPlan_Failures(t *testing.T) {
+	pf := testPackage(v1beta1.Serial, dummy("fatal", v1beta1.DummyTaskSpec{WantErr: true, Fatal: true}))
+	result, err := Plan(pf, "deploy", Options{})
+	assert.Error(t, err)
+	assert.Equal(t, v1beta1.ExecutionFatalError, result.Status.Status)
+
+	pf = testPackage(v1beta1.Serial, dummy("not-done", v1beta1.DummyTaskSpec{Done: false}), dummy("never-run", v1beta1.DummyTaskSpec{Done: true}))
+	result, err = Plan(pf, "deploy", Options{})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"main.not-done.not-done", "main.not-done.not-done"}, events(result, TaskExecuted))
+
+	_, err = Plan(pf, "missing", Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	engtask "github.com/kudobuilder/kudo/pkg/engine/task"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/verifier"
+)
+
+var _ verifier.PackageVerifier = &BuildVerifier{}
+
+// BuildVerifier checks that all tasks can be built by the engine, that task names are unique, that all tasks used by
+// plan steps are defined and that the pipe keys of a plan are unique.
+type BuildVerifier struct{}
+
+// Verify implements verifier.PackageVerifier for task verification
+func (BuildVerifier) Verify(pf *packages.Files) verifier.Result {
+	res := verifier.NewResult()
+
+	tasks := make(map[string]v1beta1.Task)
+	for i := range pf.Operator.Tasks {
+		t := pf.Operator.Tasks[i]
+		if _, ok := tasks[t.Name]; ok {
+			res.AddErrors(fmt.Sprintf("task %q is defined more than once", t.Name))
+			continue
+		}
+		tasks[t.Name] = t
+		if _, err := engtask.Build(&t); err != nil {
+			res.AddErrors(fmt.Sprintf("task %q is invalid: %v", t.Name, err))
+		}
+	}
+
+	plans := make([]string, 0, len(pf.Operator.Plans))
+	for name := range pf.Operator.Plans {
+		plans = append(plans, name)
+	}
+	sort.Strings(plans)
+
+	for _, name := range plans {
+		pipes := make(map[string]string)
+		for _, ph := range pf.Operator.Plans[name].Phases {
+			for _, st := range ph.Steps {
+				for _, tn := range st.Tasks {
+					t, ok := tasks[tn]
+					if !ok {
+						res.AddErrors(fmt.Sprintf("task %q used by step %s.%s.%s is not defined", tn, name, ph.Name, st.Name))
+						continue
+					}
+					if t.Kind != engtask.PipeTaskKind {
+						continue
+					}
+					for _, pipe := range t.Spec.PipeTaskSpec.Pipe {
+						if other, ok := pipes[pipe.Key]; ok {
+							res.AddErrors(fmt.Sprintf("pipe key %q of task %q in plan %s is already used by task %q", pipe.Key, tn, name, other))
+							continue
+						}
+						pipes[pipe.Key] = tn
+					}
+				}
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,81 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
+)
+
+func TestBuildVerifier(t *testing.T) {
+	pipeTask := func(name, key string) v1beta1.Task {
+		return v1beta1.Task{
+			Name: name,
+			Kind: "Pipe",
+			Spec: v1beta1.TaskSpec{PipeTaskSpec: v1beta1.PipeTaskSpec{
+				Pod:  "pod.yaml",
+				Pipe: []v1beta1.PipeSpec{{File: "/tmp/file", Kind: "ConfigMap", Key: key}},
+			}},
+		}
+	}
+	plan := func(tasks ...string) v1beta1.Plan {
+		return v1beta1.Plan{Phases: []v1beta1.Phase{{Name: "phase", Steps: []v1beta1.Step{{Name: "step", Tasks: tasks}}}}}
+	}
+
+	tests := []struct {
+		name           string
+		tasks          []v1beta1.Task
+		plans          map[string]v1beta1.Plan
+		expectedErrors []string
+	}{
+		{
+			name:           "valid tasks",
+			tasks:          []v1beta1.Task{{Name: "app", Kind: "Dummy"}, pipeTask("gen", "key")},
+			plans:          map[string]v1beta1.Plan{"deploy": plan("gen", "app")},
+			expectedErrors: []string{},
+		},
+		{
+			name:           "unknown task kind",
+			tasks:          []v1beta1.Task{{Name: "app", Kind: "Unknown"}},
+			plans:          map[string]v1beta1.Plan{"deploy": plan("app")},
+			expectedErrors: []string{`task "app" is invalid: unknown task kind Unknown`},
+		},
+		{
+			name:           "invalid task",
+			tasks:          []v1beta1.Task{{Name: "app", Kind: "Apply"}},
+			plans:          map[string]v1beta1.Plan{"deploy": plan("app")},
+			expectedErrors: []string{`task "app" is invalid: task validation error: apply task has an empty resource list. if that's what you need, use a Dummy task instead`},
+		},
+		{
+			name:           "duplicate task",
+			tasks:          []v1beta1.Task{{Name: "app", Kind: "Dummy"}, {Name: "app", Kind: "Dummy"}},
+			plans:          map[string]v1beta1.Plan{"deploy": plan("app")},
+			expectedErrors: []string{`task "app" is defined more than once`},
+		},
+		{
+			name:           "undefined task",
+			tasks:          []v1beta1.Task{{Name: "app", Kind: "Dummy"}},
+			plans:          map[string]v1beta1.Plan{"deploy": plan("app"), "backup": plan("backup")},
+			expectedErrors: []string{`task "backup" used by step backup.phase.step is not defined`},
+		},
+		{
+			name:           "duplicate pipe key",
+			tasks:          []v1beta1.Task{pipeTask("gen", "key"), pipeTask("gen2", "key")},
+			plans:          map[string]v1beta1.Plan{"deploy": plan("gen", "gen2"), "update": plan("gen")},
+			expectedErrors: []string{`pipe key "key" of task "gen2" in plan deploy is already used by task "gen"`},
+		},
+	}
+
+	verifier := BuildVerifier{}
+	for _, tt := range tests {
+		pf := &packages.Files{
+			Operator: &packages.OperatorFile{Tasks: tt.tasks, Plans: tt.plans},
+			Params:   &packages.ParamsFile{},
+		}
+		res := verifier.Verify(pf)
+		assert.Equal(t, tt.expectedErrors, res.Errors, tt.name)
+		assert.Equal(t, []string{}, res.Warnings, tt.name)
+	}
+}
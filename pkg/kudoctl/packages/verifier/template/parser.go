@@ -6,6 +6,7 @@ import (
 	"text/template/parse"
 
 	"github.com/kudobuilder/kudo/pkg/engine/renderer"
+	engtask "github.com/kudobuilder/kudo/pkg/engine/task"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/clog"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
 )
@@ -25,6 +26,10 @@ func getNodeMap(ts packages.Templates) nodeMap {
 	tNodes := nodeMap{}
 
 	for fname, file := range ts {
+		// embedded Helm charts are rendered by the Helm engine and not by KUDO
+		if engtask.IsChartFile(fname) {
+			continue
+		}
 
 		e := renderer.New()
 		t := e.Template(fname)
@@ -42,7 +47,10 @@ func getNodeMap(ts packages.Templates) nodeMap {
 
 		//nodeMap is a map of node types ("Implicits", "Params") to a set of that type (which is go is a map :))
 		nodeMap := map[string]map[string]bool{}
-		walkNodes(tplate.Root, fname, nodeMap)
+		// a template file can define additional named templates (e.g. partials) which are walked too
+		for _, t := range tplate.Templates() {
+			walkNodes(t.Root, fname, nodeMap)
+		}
 
 		n := nodes{
 			parameters:     values(nodeMap, "Params"),
@@ -70,6 +78,8 @@ The most significant node types that are important to KUDO and their description
 		Functions identically to IfNode however we don't have a working example.
 	parse.ListNode
 		Is a collection of nodes to process, an example is the Root node. When "walking" nodes "if" and "with" nodes could have lists of nodes.  This is a key node for traversal of the entire tree through recursion.
+	parse.TemplateNode
+		This is a node such as {{ template "labels" . }} which renders a named template, e.g. one defined in a partial. Only its Pipeline is processed here, the named template itself is walked as part of the file defining it.
 	parse.TextNode
 		This is a node that is a body of text with no template fields / nodes to evaluate.
 
@@ -99,8 +109,11 @@ func walkNodes(node parse.Node, fname string, nodeMap map[string]map[string]bool
 		for _, n := range node.Nodes {
 			walkNodes(n, fname, nodeMap)
 		}
-	case *parse.RangeNode: // no support for Range, Template or TextNodes
 	case *parse.TemplateNode:
+		if node.Pipe != nil {
+			walkPipes(node.Pipe, nodeMap)
+		}
+	case *parse.RangeNode: // no support for Range or TextNodes
 	case *parse.TextNode:
 	default:
 		clog.V(2).Printf("file %q has unknown node: %s", fname, node)
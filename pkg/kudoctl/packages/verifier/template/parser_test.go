@@ -88,3 +88,13 @@ func contains(arr []string, str string) bool {
 	}
 	return false
 }
+
+func TestTemplate_PartialParameters(t *testing.T) {
+	var templates = packages.Templates{}
+	templates["_helpers.tpl"] = `{{ define "labels" }}app: {{ .Params.App }}{{ end }}`
+	templates["example.yaml"] = `{{ template "labels" .Params.Nested }}`
+
+	tnodes := getNodeMap(templates)
+	assert.DeepEqual(t, []string{"App"}, tnodes["_helpers.tpl"].parameters)
+	assert.DeepEqual(t, []string{"Nested"}, tnodes["example.yaml"].parameters)
+}
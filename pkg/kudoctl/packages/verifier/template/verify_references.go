@@ -2,7 +2,9 @@ package template
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/kudobuilder/kudo/pkg/engine/renderer"
 	engtask "github.com/kudobuilder/kudo/pkg/engine/task"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/verifier"
@@ -11,7 +13,8 @@ import (
 var _ verifier.PackageVerifier = &ReferenceVerifier{}
 
 // ReferenceVerifier checks that all referenced templates exists (without errors)
-// and warns if a template exists but isn't referenced in a plan
+// and warns if a template exists but isn't referenced in a plan. Partials are not resources
+// and therefore must not be referenced by tasks. Embedded charts are checked the same way.
 type ReferenceVerifier struct{}
 
 func (ReferenceVerifier) Verify(pf *packages.Files) verifier.Result {
@@ -23,6 +26,7 @@ func (ReferenceVerifier) Verify(pf *packages.Files) verifier.Result {
 
 	// conflated a bit...  the loop 1) confirms that all resources are defined templates, and 2) creates a map of all resources for next verification
 	requiredTemplates := make(map[string]bool)
+	requiredCharts := make(map[string]bool)
 	for _, task := range pf.Operator.Tasks {
 		var resources []string
 		switch task.Kind {
@@ -32,18 +36,42 @@ func (ReferenceVerifier) Verify(pf *packages.Files) verifier.Result {
 			resources = task.Spec.ResourceTaskSpec.Resources
 		case engtask.PipeTaskKind:
 			resources = append(resources, task.Spec.PipeTaskSpec.Pod)
+		case engtask.HelmTaskKind:
+			if task.Spec.HelmTaskSpec.Values != "" {
+				resources = append(resources, task.Spec.HelmTaskSpec.Values)
+			}
+			requiredCharts[task.Spec.HelmTaskSpec.Chart] = true
+			if _, ok := engtask.ChartFiles(pf.Templates, task.Spec.HelmTaskSpec.Chart)["Chart.yaml"]; !ok {
+				res.AddErrors(fmt.Sprintf("chart %q required by %s but is not defined", task.Spec.HelmTaskSpec.Chart, task.Name))
+			}
 		default:
 		}
 
 		for _, r := range resources {
 			requiredTemplates[r] = true
+			if renderer.IsPartial(r) {
+				res.AddErrors(fmt.Sprintf("template %q required by %s is a partial and can not be used as a resource", r, task.Name))
+				continue
+			}
 			if _, ok := templates[r]; !ok {
 				res.AddErrors(fmt.Sprintf("template %q required by %s but is not defined", r, task.Name))
 			}
 		}
 	}
 
+	unreferencedCharts := make(map[string]bool)
 	for template := range templates {
+		if engtask.IsChartFile(template) {
+			chart := strings.SplitN(strings.TrimPrefix(template, engtask.ChartsDir+"/"), "/", 2)[0]
+			if _, ok := requiredCharts[chart]; !ok && !unreferencedCharts[chart] {
+				unreferencedCharts[chart] = true
+				res.AddWarnings(fmt.Sprintf("chart %q is not referenced from any task", chart))
+			}
+			continue
+		}
+		if renderer.IsPartial(template) {
+			continue
+		}
 		if _, ok := requiredTemplates[template]; !ok {
 			res.AddWarnings(fmt.Sprintf("template %q is not referenced from any task", template))
 		}
@@ -41,3 +41,64 @@ func TestTemplateReferenceVerifier(t *testing.T) {
 	assert.Equal(t, 1, len(res.Errors))
 	assert.Equal(t, `template "bar.yaml" required by foo but is not defined`, res.Errors[0])
 }
+
+func TestTemplateReferenceVerifier_Partials(t *testing.T) {
+	templates := map[string]string{
+		"foo.yaml":     `{{ template "labels" . }}`,
+		"_helpers.tpl": `{{ define "labels" }}app: foo{{ end }}`,
+	}
+	tasks := []v1beta1.Task{{
+		Name: "foo",
+		Kind: "Apply",
+		Spec: v1beta1.TaskSpec{
+			ResourceTaskSpec: v1beta1.ResourceTaskSpec{Resources: []string{"foo.yaml"}},
+		},
+	}, {
+		Name: "bar",
+		Kind: "Apply",
+		Spec: v1beta1.TaskSpec{
+			ResourceTaskSpec: v1beta1.ResourceTaskSpec{Resources: []string{"_helpers.tpl"}},
+		},
+	}}
+	pf := packages.Files{
+		Templates: templates,
+		Operator:  &packages.OperatorFile{Tasks: tasks},
+		Params:    &packages.ParamsFile{},
+	}
+	res := ReferenceVerifier{}.Verify(&pf)
+
+	assert.Equal(t, 0, len(res.Warnings))
+	assert.Equal(t, []string{`template "_helpers.tpl" required by bar is a partial and can not be used as a resource`}, res.Errors)
+}
+
+func TestTemplateReferenceVerifier_Charts(t *testing.T) {
+	templates := map[string]string{
+		"redis-values.yaml":                    "does not matter",
+		"charts/redis/Chart.yaml":              "does not matter",
+		"charts/redis/templates/_helpers.tpl":  "does not matter",
+		"charts/unused/Chart.yaml":             "does not matter",
+		"charts/unused/templates/service.yaml": "does not matter",
+	}
+	tasks := []v1beta1.Task{{
+		Name: "redis",
+		Kind: "Helm",
+		Spec: v1beta1.TaskSpec{
+			HelmTaskSpec: v1beta1.HelmTaskSpec{Chart: "redis", Values: "redis-values.yaml"},
+		},
+	}, {
+		Name: "missing",
+		Kind: "Helm",
+		Spec: v1beta1.TaskSpec{
+			HelmTaskSpec: v1beta1.HelmTaskSpec{Chart: "missing"},
+		},
+	}}
+	pf := packages.Files{
+		Templates: templates,
+		Operator:  &packages.OperatorFile{Tasks: tasks},
+		Params:    &packages.ParamsFile{},
+	}
+	res := ReferenceVerifier{}.Verify(&pf)
+
+	assert.Equal(t, []string{`chart "unused" is not referenced from any task`}, res.Warnings)
+	assert.Equal(t, []string{`chart "missing" required by missing but is not defined`}, res.Errors)
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/packages"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/verifier"
+	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/verifier/task"
 	"github.com/kudobuilder/kudo/pkg/kudoctl/packages/verifier/template"
 )
 
@@ -14,6 +16,7 @@ var verifiers = []verifier.PackageVerifier{
 	InvalidCharVerifier{";,"},
 	template.ParametersVerifier{},
 	template.ReferenceVerifier{},
+	task.BuildVerifier{},
 }
 
 // PackageFiles verifies operator package files
@@ -25,6 +28,22 @@ func PackageFiles(pf *packages.Files) verifier.Result {
 	return res
 }
 
+// OperatorVersion verifies an operator version installed in the cluster with the same checks as its package files
+func OperatorVersion(ov *v1beta1.OperatorVersion) verifier.Result {
+	pf := &packages.Files{
+		Templates: ov.Spec.Templates,
+		Operator: &packages.OperatorFile{
+			Name:       ov.Spec.Operator.Name,
+			Version:    ov.Spec.Version,
+			AppVersion: ov.Spec.AppVersion,
+			Tasks:      ov.Spec.Tasks,
+			Plans:      ov.Spec.Plans,
+		},
+		Params: &packages.ParamsFile{Parameters: ov.Spec.Parameters},
+	}
+	return PackageFiles(pf)
+}
+
 // DuplicateVerifier provides verification that there are no duplicates disallowing casing (Kudo and kudo are duplicates)
 type DuplicateVerifier struct{}
 
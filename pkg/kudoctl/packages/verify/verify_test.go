@@ -67,6 +67,23 @@ func TestInvalidCharVerifier(t *testing.T) {
 	}
 }
 
+func TestOperatorVersion(t *testing.T) {
+	ov := &v1beta1.OperatorVersion{
+		Spec: v1beta1.OperatorVersionSpec{
+			Templates: map[string]string{"config.yaml": "value: {{ .Params.Value }}"},
+			Tasks: []v1beta1.Task{
+				{Name: "config", Kind: "Apply", Spec: v1beta1.TaskSpec{ResourceTaskSpec: v1beta1.ResourceTaskSpec{Resources: []string{"config.yaml", "missing.yaml"}}}},
+			},
+			Plans:      map[string]v1beta1.Plan{"deploy": {Phases: []v1beta1.Phase{{Name: "deploy", Steps: []v1beta1.Step{{Name: "config", Tasks: []string{"config"}}}}}}},
+			Parameters: []v1beta1.Parameter{{Name: "Value"}, {Name: "Unused"}},
+		},
+	}
+
+	res := OperatorVersion(ov)
+	assert.Equal(t, []string{`template "missing.yaml" required by config but is not defined`}, res.Errors)
+	assert.Equal(t, []string{`parameter "Unused" defined but not used.`}, res.Warnings)
+}
+
 func packageFileForParams(params []v1beta1.Parameter) *packages.Files {
 	p := packages.ParamsFile{
 		Parameters: params,
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	v1core "k8s.io/api/core/v1"
@@ -149,18 +148,25 @@ func (c *Client) GetOperatorVersion(name, namespace string) (*v1beta1.OperatorVe
 
 // UpdateInstance updates operatorversion on instance
 func (c *Client) UpdateInstance(instanceName, namespace string, operatorVersionName *string, parameters map[string]string) error {
+	type metadata struct {
+		Labels map[string]string `json:"labels"`
+	}
+	var instanceMeta *metadata
 	instanceSpec := v1beta1.InstanceSpec{}
 	if operatorVersionName != nil {
 		instanceSpec.OperatorVersion = v1core.ObjectReference{
 			Name: kudo.StringValue(operatorVersionName),
 		}
+		instanceMeta = &metadata{Labels: map[string]string{kudo.OperatorVersionLabel: kudo.StringValue(operatorVersionName)}}
 	}
 	if parameters != nil {
 		instanceSpec.Parameters = parameters
 	}
 	serializedPatch, err := json.Marshal(struct {
-		Spec *v1beta1.InstanceSpec `json:"spec"`
+		Metadata *metadata             `json:"metadata,omitempty"`
+		Spec     *v1beta1.InstanceSpec `json:"spec"`
 	}{
+		instanceMeta,
 		&instanceSpec,
 	})
 	if err != nil {
@@ -184,6 +190,45 @@ func (c *Client) ListInstances(namespace string) ([]string, error) {
 	return existingInstances, nil
 }
 
+// InstancesOfOperatorVersion lists all instances in the cluster using the given operator version of the given ns.
+// Instances are selected by their kudo.OperatorVersionLabel and matched like in the field index of the KUDO manager
+// (see v1beta1.InstanceOperatorVersionIndex), so instances in other namespaces referencing the operator version are
+// included. If the user is not allowed to list instances in all namespaces, only the given ns is searched.
+func (c *Client) InstancesOfOperatorVersion(operatorVersionName, namespace string) ([]string, error) {
+	opts := v1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", kudo.OperatorVersionLabel, operatorVersionName)}
+	instances, err := c.clientset.KudoV1beta1().Instances("").List(opts)
+	if apierrors.IsForbidden(err) {
+		clog.V(2).Printf("not allowed to list instances in all namespaces, listing instances in %s only", namespace)
+		instances, err = c.clientset.KudoV1beta1().Instances(namespace).List(opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ov := types.NamespacedName{Namespace: namespace, Name: operatorVersionName}
+	existingInstances := []string{}
+
+	for _, i := range instances.Items {
+		if i.OperatorVersionNamespacedName() != ov {
+			continue
+		}
+		if i.Namespace == namespace {
+			existingInstances = append(existingInstances, i.Name)
+		} else {
+			existingInstances = append(existingInstances, fmt.Sprintf("%s/%s", i.Namespace, i.Name))
+		}
+	}
+	return existingInstances, nil
+}
+
+// ListOperators lists all operators installed in the given ns, their status contains the installed versions
+func (c *Client) ListOperators(namespace string) ([]v1beta1.Operator, error) {
+	operators, err := c.clientset.KudoV1beta1().Operators(namespace).List(v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return operators.Items, nil
+}
+
 // OperatorVersionsInstalled lists all the versions of given operator installed in the cluster in given ns
 func (c *Client) OperatorVersionsInstalled(operatorName, namespace string) ([]string, error) {
 	ov, err := c.clientset.KudoV1beta1().OperatorVersions(namespace).List(v1.ListOptions{})
@@ -193,7 +238,9 @@ func (c *Client) OperatorVersionsInstalled(operatorName, namespace string) ([]st
 	existingVersions := []string{}
 
 	for _, v := range ov.Items {
-		if strings.HasPrefix(v.Name, operatorName) {
+		// the name of an operator version starts with the operator name, but so do the names of operators sharing
+		// its prefix, e.g. kafka and kafka-connect. the referenced operator identifies the operator version.
+		if v.Spec.Operator.Name == operatorName {
 			existingVersions = append(existingVersions, v.Spec.Version)
 		}
 	}
@@ -1,13 +1,18 @@
 package kudo
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"testing"
 
 	"gotest.tools/assert"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testcore "k8s.io/client-go/testing"
 
 	"github.com/kudobuilder/kudo/pkg/apis/kudo/v1beta1"
 	"github.com/kudobuilder/kudo/pkg/client/clientset/versioned/fake"
@@ -199,6 +204,69 @@ func TestKudoClient_ListInstances(t *testing.T) {
 	}
 }
 
+func TestKudoClient_InstancesOfOperatorVersion(t *testing.T) {
+	instance := func(namespace, name, ovNamespace, ovName string) *v1beta1.Instance {
+		return &v1beta1.Instance{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "kudo.dev/v1beta1",
+				Kind:       "Instance",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{kudo.OperatorVersionLabel: ovName},
+			},
+			Spec: v1beta1.InstanceSpec{
+				OperatorVersion: v1.ObjectReference{Name: ovName, Namespace: ovNamespace},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		forbidden bool
+		expected  []string
+	}{
+		{"all namespaces", false, []string{"shared/shared", "test"}},
+		{"forbidden to list all namespaces", true, []string{"test"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			if tt.forbidden {
+				clientset.PrependReactor("list", "instances", func(action testcore.Action) (bool, runtime.Object, error) {
+					if action.GetNamespace() != "" {
+						return false, nil, nil
+					}
+					return true, nil, apierrors.NewForbidden(v1beta1.Resource("instances"), "", errors.New("cluster-wide list"))
+				})
+			}
+			k2o := NewClientFromK8s(clientset)
+
+			unlabeled := instance("default", "unlabeled", "", "test-1.0")
+			unlabeled.Labels = nil
+			for _, i := range []*v1beta1.Instance{
+				instance("default", "test", "", "test-1.0"),
+				instance("default", "upgraded", "", "test-2.0"),
+				instance("other", "other", "", "test-1.0"),
+				instance("shared", "shared", "default", "test-1.0"),
+				unlabeled,
+			} {
+				if _, err := k2o.clientset.KudoV1beta1().Instances(i.Namespace).Create(i); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			instances, err := k2o.InstancesOfOperatorVersion("test-1.0", "default")
+			assert.NilError(t, err)
+			sort.Strings(instances)
+			assert.DeepEqual(t, tt.expected, instances)
+		})
+	}
+}
+
 func TestKudoClient_OperatorVersionsInstalled(t *testing.T) {
 	operatorName := "test"
 	obj := v1beta1.OperatorVersion{
@@ -210,7 +278,18 @@ func TestKudoClient_OperatorVersionsInstalled(t *testing.T) {
 			Name: fmt.Sprintf("%s-1.0", operatorName),
 		},
 		Spec: v1beta1.OperatorVersionSpec{
-			Version: "1.0",
+			Operator: v1.ObjectReference{Name: operatorName},
+			Version:  "1.0",
+		},
+	}
+	otherOperator := v1beta1.OperatorVersion{
+		TypeMeta: obj.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-other-1.0", operatorName),
+		},
+		Spec: v1beta1.OperatorVersionSpec{
+			Operator: v1.ObjectReference{Name: operatorName + "-other"},
+			Version:  "1.0",
 		},
 	}
 
@@ -224,6 +303,7 @@ func TestKudoClient_OperatorVersionsInstalled(t *testing.T) {
 		{"no operator version defined", []string{}, installNamespace, nil},
 		{"operator version exists in the same namespace", []string{obj.Spec.Version}, installNamespace, &obj},
 		{"operator version exists in different namespace", []string{}, "otherns", &obj},
+		{"operator version of operator with same prefix exists", []string{}, installNamespace, &otherOperator},
 	}
 
 	for _, tt := range tests {
@@ -512,6 +592,9 @@ func TestKudoClient_UpdateOperatorVersion(t *testing.T) {
 			if err != nil || instance.Spec.OperatorVersion.Name != util.StringValue(tt.patchToVersion) {
 				t.Errorf("%s:\nexpected version: %v\n     got: %v, err: %v", tt.name, util.StringValue(tt.patchToVersion), instance.Spec.OperatorVersion.Name, err)
 			}
+			if instance.Labels[kudo.OperatorVersionLabel] != util.StringValue(tt.patchToVersion) || instance.Labels[kudo.OperatorLabel] != "test" {
+				t.Errorf("%s:\nexpected version label: %v\n     got labels: %v", tt.name, util.StringValue(tt.patchToVersion), instance.Labels)
+			}
 		} else {
 			if instance.Spec.OperatorVersion.Name != testInstance.Spec.OperatorVersion.Name {
 				t.Errorf("%s:\nexpected version to not change from: %v\n err: %v", tt.name, instance.Spec.OperatorVersion.Name, err)
@@ -0,0 +1,33 @@
+// Package metrics contains KUDO specific prometheus metrics. All metrics are registered with the controller-runtime
+// registry and are therefore exposed on the manager metrics endpoint alongside the default controller metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Apply actions recorded by the ApplyObjects metric
+const (
+	ApplyActionCreated = "created"
+	ApplyActionPatched = "patched"
+	ApplyActionSkipped = "skipped"
+)
+
+var (
+	// ApplyObjects counts objects handled by Apply tasks, partitioned by the action taken: an object can be
+	// created, patched or its patch can be skipped because the live object is already up to date.
+	ApplyObjects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "kudo",
+			Subsystem: "task",
+			Name:      "apply_objects_total",
+			Help:      "Number of objects handled by apply tasks, partitioned by action (created, patched, skipped).",
+		},
+		[]string{"action"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(ApplyObjects)
+}
@@ -0,0 +1,207 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Plan results recorded by the PlanExecutionsFinished and PlanDuration metrics
+const (
+	PlanResultComplete   = "complete"
+	PlanResultFatalError = "fatal_error"
+)
+
+// durationBuckets range from one second to about an hour
+var durationBuckets = prometheus.ExponentialBuckets(1, 2, 13)
+
+var (
+	// PlanExecutionsStarted counts plan executions started by the instance controller.
+	PlanExecutionsStarted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "kudo",
+			Subsystem: "plan",
+			Name:      "executions_started_total",
+			Help:      "Number of started plan executions, partitioned by operator and plan.",
+		},
+		[]string{"operator", "plan"},
+	)
+
+	// PlanExecutionsFinished counts finished plan executions, partitioned by their result: a plan either completes
+	// or fails with a fatal error.
+	PlanExecutionsFinished = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "kudo",
+			Subsystem: "plan",
+			Name:      "executions_finished_total",
+			Help:      "Number of finished plan executions, partitioned by operator, plan and result (complete, fatal_error).",
+		},
+		[]string{"operator", "plan", "result"},
+	)
+
+	// PlansInProgress is the number of plan executions currently in progress.
+	PlansInProgress = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "kudo",
+			Subsystem: "plan",
+			Name:      "executions_in_progress",
+			Help:      "Number of plan executions in progress, partitioned by operator and plan.",
+		},
+		[]string{"operator", "plan"},
+	)
+
+	// PlanDuration observes the duration of finished plan executions.
+	PlanDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "kudo",
+			Subsystem: "plan",
+			Name:      "duration_seconds",
+			Help:      "Duration of finished plan executions, partitioned by operator, plan and result (complete, fatal_error).",
+			Buckets:   durationBuckets,
+		},
+		[]string{"operator", "plan", "result"},
+	)
+
+	// PhaseDuration observes the duration of completed plan phases.
+	PhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "kudo",
+			Subsystem: "phase",
+			Name:      "duration_seconds",
+			Help:      "Duration of completed plan phases, partitioned by operator, plan and phase.",
+			Buckets:   durationBuckets,
+		},
+		[]string{"operator", "plan", "phase"},
+	)
+
+	// StepDuration observes the duration of completed plan steps.
+	StepDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "kudo",
+			Subsystem: "step",
+			Name:      "duration_seconds",
+			Help:      "Duration of completed plan steps, partitioned by operator, plan, phase and step.",
+			Buckets:   durationBuckets,
+		},
+		[]string{"operator", "plan", "phase", "step"},
+	)
+
+	// TaskTransientErrors counts transient task errors, after which the task execution is retried.
+	TaskTransientErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "kudo",
+			Subsystem: "task",
+			Name:      "transient_errors_total",
+			Help:      "Number of transient task execution errors, partitioned by task kind.",
+		},
+		[]string{"kind"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(PlanExecutionsStarted, PlanExecutionsFinished, PlansInProgress, PlanDuration, PhaseDuration, StepDuration, TaskTransientErrors)
+}
+
+// PlanExecution is a plan execution of an instance tracked for the plan metrics. It is tracked from the first time
+// it is executed until its finished status is persisted, and keeps the start times of the plan and its phases and steps in memory.
+// Executions which were already running when the manager started have no start times, their durations are not
+// observed.
+type PlanExecution struct {
+	instance string
+	uid      types.UID
+	operator string
+	plan     string
+	starts   map[string]time.Time
+}
+
+var (
+	executionsMu sync.Mutex
+	executions   = map[string]*PlanExecution{}
+)
+
+// TrackPlan returns the tracked plan execution of an instance, identified by its namespaced name. A new execution
+// is tracked and counted as in progress if the instance has none, or if its tracked execution has a different plan
+// UID, e.g. because the cleanup plan replaced a running plan.
+func TrackPlan(instance, operator, plan string, uid types.UID) *PlanExecution {
+	executionsMu.Lock()
+	defer executionsMu.Unlock()
+
+	if e, ok := executions[instance]; ok {
+		if e.uid == uid {
+			return e
+		}
+		PlansInProgress.WithLabelValues(e.operator, e.plan).Dec()
+	}
+	e := &PlanExecution{instance: instance, uid: uid, operator: operator, plan: plan, starts: map[string]time.Time{}}
+	executions[instance] = e
+	PlansInProgress.WithLabelValues(operator, plan).Inc()
+	return e
+}
+
+// ForgetInstance stops tracking the plan execution of a deleted instance.
+func ForgetInstance(instance string) {
+	executionsMu.Lock()
+	defer executionsMu.Unlock()
+
+	if e, ok := executions[instance]; ok {
+		PlansInProgress.WithLabelValues(e.operator, e.plan).Dec()
+		delete(executions, instance)
+	}
+}
+
+// Start records the start of the plan, if phase and step are empty, of a phase, if step is empty, or of a step.
+func (e *PlanExecution) Start(phase, step string, t time.Time) {
+	executionsMu.Lock()
+	defer executionsMu.Unlock()
+
+	e.starts[phase+"/"+step] = t
+}
+
+// Complete observes the duration of a completed phase, if step is empty, or step.
+func (e *PlanExecution) Complete(phase, step string, t time.Time) {
+	d, ok := e.since(phase+"/"+step, t)
+	if !ok {
+		return
+	}
+	if step == "" {
+		PhaseDuration.WithLabelValues(e.operator, e.plan, phase).Observe(d.Seconds())
+	} else {
+		StepDuration.WithLabelValues(e.operator, e.plan, phase, step).Observe(d.Seconds())
+	}
+}
+
+// FinishPlan records the result of the tracked plan execution of an instance with the given plan UID and stops
+// tracking it. It is called once the finished plan status is persisted: the execution is only finished once, and a
+// plan that is executed again because the status update failed isn't counted twice. Executions that aren't tracked
+// are not recorded.
+func FinishPlan(instance string, uid types.UID, result string, t time.Time) {
+	executionsMu.Lock()
+	e, ok := executions[instance]
+	if !ok || e.uid != uid {
+		executionsMu.Unlock()
+		return
+	}
+	PlansInProgress.WithLabelValues(e.operator, e.plan).Dec()
+	delete(executions, instance)
+	executionsMu.Unlock()
+
+	PlanExecutionsFinished.WithLabelValues(e.operator, e.plan, result).Inc()
+	if d, ok := e.since("/", t); ok {
+		PlanDuration.WithLabelValues(e.operator, e.plan, result).Observe(d.Seconds())
+	}
+}
+
+func (e *PlanExecution) since(key string, t time.Time) (time.Duration, bool) {
+	executionsMu.Lock()
+	defer executionsMu.Unlock()
+
+	start, ok := e.starts[key]
+	if !ok {
+		return 0, false
+	}
+	delete(e.starts, key)
+	return t.Sub(start), true
+}
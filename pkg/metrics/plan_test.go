@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleCount(t *testing.T, o prometheus.Observer) uint64 {
+	m := &dto.Metric{}
+	assert.NoError(t, o.(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestPlanExecution(t *testing.T) {
+	start := time.Now()
+
+	e := TrackPlan("default/zk", "zookeeper", "deploy", "1")
+	e.Start("", "", start)
+	e.Start("main", "", start)
+	e.Start("main", "everything", start)
+	assert.Same(t, e, TrackPlan("default/zk", "zookeeper", "deploy", "1"))
+	assert.Equal(t, 1.0, testutil.ToFloat64(PlansInProgress.WithLabelValues("zookeeper", "deploy")))
+
+	e.Complete("main", "everything", start.Add(time.Second))
+	e.Complete("main", "", start.Add(2*time.Second))
+	// completing again without a new start is not observed
+	e.Complete("main", "", start.Add(3*time.Second))
+	FinishPlan("default/zk", "1", PlanResultComplete, start.Add(3*time.Second))
+	// the execution is only finished once
+	FinishPlan("default/zk", "1", PlanResultComplete, start.Add(4*time.Second))
+
+	assert.Equal(t, uint64(1), sampleCount(t, StepDuration.WithLabelValues("zookeeper", "deploy", "main", "everything")))
+	assert.Equal(t, uint64(1), sampleCount(t, PhaseDuration.WithLabelValues("zookeeper", "deploy", "main")))
+	assert.Equal(t, uint64(1), sampleCount(t, PlanDuration.WithLabelValues("zookeeper", "deploy", PlanResultComplete)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(PlanExecutionsFinished.WithLabelValues("zookeeper", "deploy", PlanResultComplete)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(PlansInProgress.WithLabelValues("zookeeper", "deploy")))
+}
+
+func TestTrackPlan_Replaced(t *testing.T) {
+	TrackPlan("default/kafka", "kafka", "update", "1")
+	// the execution of a resumed plan has no start and its duration is not observed
+	e := TrackPlan("default/kafka", "kafka", "cleanup", "2")
+	assert.Equal(t, 0.0, testutil.ToFloat64(PlansInProgress.WithLabelValues("kafka", "update")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(PlansInProgress.WithLabelValues("kafka", "cleanup")))
+
+	// finishing a replaced execution is not recorded
+	FinishPlan("default/kafka", "1", PlanResultComplete, time.Now())
+	assert.Equal(t, 0.0, testutil.ToFloat64(PlanExecutionsFinished.WithLabelValues("kafka", "update", PlanResultComplete)))
+
+	FinishPlan("default/kafka", e.uid, PlanResultFatalError, time.Now())
+	assert.Equal(t, 1.0, testutil.ToFloat64(PlanExecutionsFinished.WithLabelValues("kafka", "cleanup", PlanResultFatalError)))
+	assert.Equal(t, uint64(0), sampleCount(t, PlanDuration.WithLabelValues("kafka", "cleanup", PlanResultFatalError)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(PlansInProgress.WithLabelValues("kafka", "cleanup")))
+
+	TrackPlan("default/kafka", "kafka", "deploy", "3")
+	ForgetInstance("default/kafka")
+	assert.Equal(t, 0.0, testutil.ToFloat64(PlansInProgress.WithLabelValues("kafka", "deploy")))
+}
@@ -279,11 +279,17 @@ func (h *Harness) RunKUDO() error {
 		os.Exit(1)
 	}
 
+	dclient, err := h.DiscoveryClient()
+	if err != nil {
+		return err
+	}
+
 	h.logger.Log("Setting up instance controller")
 	err = (&instance.Reconciler{
-		Client:   mgr.GetClient(),
-		Recorder: mgr.GetEventRecorderFor("instance-controller"),
-		Scheme:   mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Discovery: dclient,
+		Recorder:  mgr.GetEventRecorderFor("instance-controller"),
+		Scheme:    mgr.GetScheme(),
 	}).SetupWithManager(mgr)
 	if err != nil {
 		h.logger.Log(err, "unable to register instance controller to the manager")
@@ -3,10 +3,16 @@ package kudo
 const (
 	// OperatorLabel is k8s label key for identifying operator
 	OperatorLabel = "kudo.dev/operator"
+	// OperatorVersionLabel is k8s label key on instances for the name of their operator version. It is set by the CLI
+	// when an instance is installed or upgraded
+	OperatorVersionLabel = "kudo.dev/operator-version-name"
 	// OperatorVersionAnnotation is k8s label key for operator version
 	OperatorVersionAnnotation = "kudo.dev/operator-version"
 	// InstanceLabel is k8s label key for KUDO instance name
 	InstanceLabel = "kudo.dev/instance"
+	// InstanceNamespaceLabel is k8s label key for the namespace of the KUDO instance. It is only set on resources that
+	// can not be owned by the instance (cluster-scoped resources or resources in other namespaces)
+	InstanceNamespaceLabel = "kudo.dev/instance-namespace"
 	// HeritageLabel is k8s label key for heritage
 	HeritageLabel = "heritage" // this is not specific to KUDO
 
@@ -19,4 +25,27 @@ const (
 
 	// PlanUIDAnnotation is a k8s annotation key for the last time a given plan was run on the referenced object
 	PlanUIDAnnotation = "kudo.dev/last-plan-execution-uid"
+
+	// ContentHashAnnotation is a k8s annotation key for the hash of the rendered object content. It is used to skip
+	// patching objects that didn't change since they were last applied
+	ContentHashAnnotation = "kudo.dev/content-hash"
+	// LastAppliedAnnotation is a k8s annotation key for the last time a given object was created or patched by KUDO
+	LastAppliedAnnotation = "kudo.dev/last-applied-at"
+	// RecreatePolicyAnnotation is a k8s annotation key for the policy used when a patch of the object is rejected
+	// because it changes an immutable field
+	RecreatePolicyAnnotation = "kudo.dev/recreate-policy"
+	// ClusterScopedAnnotation is a k8s annotation key marking a template object as cluster-scoped. Such an object is
+	// created without a namespace
+	ClusterScopedAnnotation = "kudo.dev/cluster-scoped"
+	// TargetNamespaceAnnotation is a k8s annotation key for the namespace a template object is created in, when it
+	// differs from the instance namespace
+	TargetNamespaceAnnotation = "kudo.dev/target-namespace"
+	// UnownedResourcesAnnotation is a k8s annotation key on instances for a comma separated list of the kinds of
+	// resources the instance can not own (see InstanceNamespaceLabel), formatted as apiVersion/kind
+	UnownedResourcesAnnotation = "kudo.dev/unowned-resources"
+	// ApplyPolicyAnnotation is a k8s annotation key for the policy used when applying an already existing object
+	ApplyPolicyAnnotation = "kudo.dev/apply-policy"
+	// IgnoreFieldsAnnotation is a k8s annotation key for a comma separated list of fields that are never patched, either
+	// dot separated or JSON pointer paths
+	IgnoreFieldsAnnotation = "kudo.dev/ignore-fields"
 )